@@ -0,0 +1,137 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+// ErrCircuitOpen is returned without calling the wrapped notifier while the circuit is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	Closed   State = iota // calls go through, failures are counted
+	Open                  // calls are short-circuited until the cooldown passes
+	HalfOpen              // one probe call decides between Closed and Open
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker wraps a Notifier and stops calling it after Threshold
+// consecutive failures, until Cooldown has passed.
+// It is itself a Notifier, so it can be used anywhere the wrapped one was.
+type CircuitBreaker struct {
+	Notifier  notification.Notifier
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewCircuitBreaker(n notification.Notifier, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Notifier: n, Threshold: threshold, Cooldown: cooldown}
+}
+
+func (cb *CircuitBreaker) Send(message string) error {
+	if err := cb.before(); err != nil {
+		return err
+	}
+	err := cb.Notifier.Send(message)
+	cb.after(err)
+	return err
+}
+
+func (cb *CircuitBreaker) SendNotification(n notification.Notification) error {
+	if err := cb.before(); err != nil {
+		return err
+	}
+	err := cb.Notifier.SendNotification(n)
+	cb.after(err)
+	return err
+}
+
+func (cb *CircuitBreaker) GetType() string {
+	return fmt.Sprintf("%s (circuit %s)", cb.Notifier.GetType(), cb.State())
+}
+
+// State reports the current state, moving Open to HalfOpen once the cooldown is over
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.refresh()
+	return cb.state
+}
+
+func (cb *CircuitBreaker) before() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.refresh()
+
+	switch cb.state {
+	case Open:
+		return ErrCircuitOpen
+	case HalfOpen:
+		// only a single probe at a time, everyone else is still short-circuited
+		if cb.probing {
+			return ErrCircuitOpen
+		}
+		cb.probing = true
+	}
+	return nil
+}
+
+func (cb *CircuitBreaker) after(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == HalfOpen {
+		cb.probing = false
+		if err != nil {
+			cb.trip()
+			return
+		}
+		cb.state = Closed
+		cb.failures = 0
+		return
+	}
+
+	if err == nil {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.Threshold {
+		cb.trip()
+	}
+}
+
+func (cb *CircuitBreaker) trip() {
+	cb.state = Open
+	cb.openedAt = time.Now()
+	cb.failures = 0
+}
+
+func (cb *CircuitBreaker) refresh() {
+	if cb.state == Open && time.Since(cb.openedAt) >= cb.Cooldown {
+		cb.state = HalfOpen
+	}
+}
@@ -0,0 +1,104 @@
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Policy decides what Publish does when a subscriber's queue is full
+type Policy int
+
+const (
+	Block Policy = iota // wait until the slow subscriber catches up
+	Drop                // discard the event for that subscriber only
+)
+
+// Handler processes one event; each subscriber runs in its own goroutine
+type Handler[T any] func(event T)
+
+type subscriber[T any] struct {
+	queue   chan T
+	handler Handler[T]
+}
+
+// EventBus is an in-process pub/sub: publishers emit typed events and
+// every subscriber receives them asynchronously through a bounded queue.
+type EventBus[T any] struct {
+	queueSize int
+	policy    Policy
+
+	mu          sync.RWMutex
+	subscribers []*subscriber[T]
+	closed      bool
+	wg          sync.WaitGroup
+	dropped     atomic.Int64
+}
+
+func New[T any](queueSize int, policy Policy) *EventBus[T] {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	return &EventBus[T]{queueSize: queueSize, policy: policy}
+}
+
+// Subscribe registers a handler and starts its worker goroutine
+func (b *EventBus[T]) Subscribe(handler Handler[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	sub := &subscriber[T]{queue: make(chan T, b.queueSize), handler: handler}
+	b.subscribers = append(b.subscribers, sub)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for event := range sub.queue {
+			sub.handler(event)
+		}
+	}()
+}
+
+// Publish hands the event to every subscriber's queue
+func (b *EventBus[T]) Publish(event T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+
+	for _, sub := range b.subscribers {
+		if b.policy == Block {
+			sub.queue <- event
+			continue
+		}
+		select {
+		case sub.queue <- event:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped returns how many deliveries were discarded by the Drop policy
+func (b *EventBus[T]) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close stops accepting events and waits until all queued events are handled
+func (b *EventBus[T]) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, sub := range b.subscribers {
+		close(sub.queue)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
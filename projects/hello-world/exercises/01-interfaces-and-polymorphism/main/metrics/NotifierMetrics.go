@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+// Values of the result label
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// NotifierMetrics holds the delivery counters and latency histogram shared by every wrapped notifier
+type NotifierMetrics struct {
+	sent    *prometheus.CounterVec
+	latency *prometheus.HistogramVec
+}
+
+// NewNotifierMetrics registers notifications_sent_total{type,result} and
+// notification_send_duration_seconds{type} with reg
+func NewNotifierMetrics(reg prometheus.Registerer) (*NotifierMetrics, error) {
+	m := &NotifierMetrics{
+		sent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "notifications_sent_total",
+			Help: "Notifications sent, by notifier type and result (success or failure).",
+		}, []string{"type", "result"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "notification_send_duration_seconds",
+			Help:    "Time taken to send one notification, by notifier type.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"type"}),
+	}
+	for _, c := range []prometheus.Collector{m.sent, m.latency} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Wrap returns n with every send recorded.
+// Wrap the real notifier, not a CircuitBreaker: its GetType includes the circuit state,
+// which would split one notifier across several label values.
+func (m *NotifierMetrics) Wrap(n notification.Notifier) *MeteredNotifier {
+	return &MeteredNotifier{Notifier: n, metrics: m}
+}
+
+// MeteredNotifier is a Notifier decorator that records NotifierMetrics
+type MeteredNotifier struct {
+	notification.Notifier
+	metrics *NotifierMetrics
+}
+
+func (mn *MeteredNotifier) Send(message string) error {
+	start := time.Now()
+	err := mn.Notifier.Send(message)
+	mn.metrics.observe(mn.GetType(), start, err)
+	return err
+}
+
+func (mn *MeteredNotifier) SendNotification(n notification.Notification) error {
+	start := time.Now()
+	err := mn.Notifier.SendNotification(n)
+	mn.metrics.observe(mn.GetType(), start, err)
+	return err
+}
+
+func (m *NotifierMetrics) observe(notifierType string, start time.Time, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailure
+	}
+	m.sent.WithLabelValues(notifierType, result).Inc()
+	m.latency.WithLabelValues(notifierType).Observe(time.Since(start).Seconds())
+}
+
+// Handler serves the metrics in g for a /metrics endpoint
+// Pass prometheus.DefaultGatherer when the metrics were registered with the default registry
+func Handler(g prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(g, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DeadLetter is a notification that could not be delivered, kept for reprocessing
+type DeadLetter struct {
+	NotifierType string
+	Notification Notification
+	Err          error
+	FailedAt     time.Time
+}
+
+// DeadLetterStore holds failed notifications until they are replayed
+type DeadLetterStore interface {
+	Add(letter DeadLetter)
+	Drain() []DeadLetter // removes and returns every letter, oldest first
+	Len() int
+}
+
+// MemoryDeadLetterStore keeps dead letters in memory; they are lost on restart
+type MemoryDeadLetterStore struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+func (s *MemoryDeadLetterStore) Add(letter DeadLetter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.letters = append(s.letters, letter)
+}
+
+func (s *MemoryDeadLetterStore) Drain() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	letters := s.letters
+	s.letters = nil
+	return letters
+}
+
+func (s *MemoryDeadLetterStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.letters)
+}
+
+// DeadLetterNotifier wraps a Notifier, retrying failed sends Retries more times
+// before recording the notification in Store. The error is still returned.
+// It is itself a Notifier, so it can be used anywhere the wrapped one was.
+type DeadLetterNotifier struct {
+	Notifier Notifier
+	Store    DeadLetterStore
+	Retries  int
+}
+
+func NewDeadLetterNotifier(n Notifier, store DeadLetterStore, retries int) *DeadLetterNotifier {
+	return &DeadLetterNotifier{Notifier: n, Store: store, Retries: retries}
+}
+
+func (d *DeadLetterNotifier) Send(message string) error {
+	return d.SendNotification(Notification{Message: message, Severity: SeverityInfo})
+}
+
+func (d *DeadLetterNotifier) SendNotification(n Notification) error {
+	var err error
+	for attempt := 0; attempt <= d.Retries; attempt++ {
+		if err = d.Notifier.SendNotification(n); err == nil {
+			return nil
+		}
+	}
+	d.Store.Add(DeadLetter{
+		NotifierType: d.Notifier.GetType(),
+		Notification: n,
+		Err:          err,
+		FailedAt:     time.Now(),
+	})
+	return err
+}
+
+func (d *DeadLetterNotifier) GetType() string {
+	return d.Notifier.GetType()
+}
+
+// Replay hands every dead letter to fn, e.g. to resend it once the endpoint is back.
+// Letters fn fails on go back into the store with the new error; those errors are joined.
+func (d *DeadLetterNotifier) Replay(fn func(DeadLetter) error) error {
+	var errs []error
+	for _, letter := range d.Store.Drain() {
+		if err := fn(letter); err != nil {
+			letter.Err = err
+			letter.FailedAt = time.Now()
+			d.Store.Add(letter)
+			errs = append(errs, fmt.Errorf("%s: %w", letter.NotifierType, err))
+		}
+	}
+	return errors.Join(errs...)
+}
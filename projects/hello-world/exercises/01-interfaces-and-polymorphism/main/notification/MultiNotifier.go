@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiNotifier fans a message out to all its children, so a group
+// can be used anywhere a single Notifier is expected.
+type MultiNotifier struct {
+	Notifiers []Notifier
+}
+
+func (m *MultiNotifier) Send(message string) error {
+	return m.SendNotification(Notification{Message: message, Severity: SeverityInfo})
+}
+
+// SendNotification always tries every child; failures are joined into one error
+func (m *MultiNotifier) SendNotification(n Notification) error {
+	var errs []error
+	for _, notifier := range m.Notifiers {
+		if err := notifier.SendNotification(n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.GetType(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *MultiNotifier) GetType() string {
+	types := make([]string, len(m.Notifiers))
+	for i, notifier := range m.Notifiers {
+		types[i] = notifier.GetType()
+	}
+	return "Multi(" + strings.Join(types, ", ") + ")"
+}
@@ -1,19 +1,71 @@
 package notification
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Notification is a message plus the metadata notifiers can use for formatting
+type Notification struct {
+	Message  string
+	Severity string
+	Tags     map[string]string
+}
+
+// severity defaults an empty severity to info
+func (n Notification) severity() string {
+	if n.Severity == "" {
+		return SeverityInfo
+	}
+	return n.Severity
+}
+
+// tagString renders tags as "k1=v1 k2=v2" in a stable order
+func (n Notification) tagString() string {
+	pairs := make([]string, 0, len(n.Tags))
+	for key, value := range n.Tags {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
 
 type Notifier interface {
 	Send(message string) error
+	SendNotification(n Notification) error
 	GetType() string
 }
 
+// output falls back to stdout when no writer is configured
+func output(w io.Writer) io.Writer {
+	if w == nil {
+		return os.Stdout
+	}
+	return w
+}
+
 type EmailNotifier struct {
 	Sender   string
 	Receiver string
+	Out      io.Writer // defaults to stdout
 }
 
 func (e *EmailNotifier) Send(message string) error {
-	fmt.Println("Sending Email to ", e.Sender, " from ", e.Receiver, ": ", message)
+	return e.SendNotification(Notification{Message: message, Severity: SeverityInfo})
+}
+
+func (e *EmailNotifier) SendNotification(n Notification) error {
+	subject := fmt.Sprintf("[%s] Notification", strings.ToUpper(n.severity()))
+	fmt.Fprintln(output(e.Out), "Sending Email to ", e.Sender, " from ", e.Receiver, " subject ", subject, ": ", n.Message)
 	return nil
 }
 
@@ -23,10 +75,15 @@ func (e *EmailNotifier) GetType() string {
 
 type SMSNotifier struct {
 	PhoneNumber string
+	Out         io.Writer // defaults to stdout
 }
 
 func (s *SMSNotifier) Send(message string) error {
-	fmt.Println("Sending SMS to ", s.PhoneNumber, ": ", message)
+	return s.SendNotification(Notification{Message: message, Severity: SeverityInfo})
+}
+
+func (s *SMSNotifier) SendNotification(n Notification) error {
+	fmt.Fprintln(output(s.Out), "Sending SMS to ", s.PhoneNumber, ": ", strings.ToUpper(n.severity())+":", n.Message)
 	return nil
 }
 
@@ -37,10 +94,23 @@ func (s *SMSNotifier) GetType() string {
 type SlackNotifier struct {
 	Channel    string
 	WebhookUrl string
+	Out        io.Writer // defaults to stdout
 }
 
 func (sl *SlackNotifier) Send(message string) error {
-	fmt.Println("Sending Slack to ", sl.Channel, ": ", message)
+	return sl.SendNotification(Notification{Message: message, Severity: SeverityInfo})
+}
+
+// SendNotification color-codes the Slack attachment by severity
+func (sl *SlackNotifier) SendNotification(n Notification) error {
+	color := "good"
+	switch n.severity() {
+	case SeverityWarning:
+		color = "warning"
+	case SeverityCritical:
+		color = "danger"
+	}
+	fmt.Fprintln(output(sl.Out), "Sending Slack to ", sl.Channel, " color ", color, ": ", n.Message, n.tagString())
 	return nil
 }
 
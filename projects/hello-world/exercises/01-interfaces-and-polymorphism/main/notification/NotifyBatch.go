@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"errors"
+	"sync"
+)
+
+// Recipient is one target of a batch send
+type Recipient struct {
+	Notifier Notifier
+	Message  string // overrides the batch's default message when set
+}
+
+// NotifyBatch sends to every recipient concurrently and waits for all of them.
+// The returned errors are index-aligned with recipients: errs[i] is nil when
+// recipients[i] was notified successfully.
+func NotifyBatch(recipients []Recipient, defaultMessage string) []error {
+	errs := make([]error, len(recipients))
+	var wg sync.WaitGroup
+	for i, r := range recipients {
+		if r.Notifier == nil {
+			errs[i] = errors.New("recipient has no notifier")
+			continue
+		}
+		message := r.Message
+		if message == "" {
+			message = defaultMessage
+		}
+		wg.Add(1)
+		go func(i int, n Notifier, message string) {
+			defer wg.Done()
+			errs[i] = n.Send(message) // each goroutine owns its own slot
+		}(i, r.Notifier, message)
+	}
+	wg.Wait()
+	return errs
+}
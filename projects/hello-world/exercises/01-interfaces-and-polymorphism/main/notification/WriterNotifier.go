@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// WriterNotifier writes every message to W, one line per message:
+//
+//	2024-01-02T15:04:05Z [Writer Notifier] INFO: System Alert: Server is down! env=prod
+//
+// Point it at a file for an audit trail or at a bytes.Buffer in tests.
+type WriterNotifier struct {
+	W io.Writer
+}
+
+func (w *WriterNotifier) Send(message string) error {
+	return w.SendNotification(Notification{Message: message, Severity: SeverityInfo})
+}
+
+func (w *WriterNotifier) SendNotification(n Notification) error {
+	line := fmt.Sprintf("%s [%s] %s: %s", time.Now().UTC().Format(time.RFC3339), w.GetType(), strings.ToUpper(n.severity()), n.Message)
+	if tags := n.tagString(); tags != "" {
+		line += " " + tags
+	}
+	_, err := fmt.Fprintln(w.W, line)
+	return err
+}
+
+func (w *WriterNotifier) GetType() string {
+	return "Writer Notifier"
+}
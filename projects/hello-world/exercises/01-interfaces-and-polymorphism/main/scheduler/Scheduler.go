@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+// Job is the handle for one scheduled notification
+type Job struct {
+	At time.Time
+
+	notifier notification.Notifier
+	message  string
+	s        *Scheduler
+
+	index     int           // position in the heap, -1 once fired or cancelled
+	done      chan struct{} // closed after the send (or the cancellation)
+	err       error
+	cancelled bool
+}
+
+// Done is closed once the job has been sent or cancelled
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Err is the send error; only meaningful after Done is closed
+func (j *Job) Err() error {
+	j.s.mu.Lock()
+	defer j.s.mu.Unlock()
+	return j.err
+}
+
+// Cancel stops a pending job. It reports false if the job already fired or was cancelled.
+func (j *Job) Cancel() bool {
+	s := j.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if j.index < 0 {
+		return false
+	}
+	heap.Remove(&s.jobs, j.index)
+	j.cancelled = true
+	close(j.done)
+	s.poke()
+	return true
+}
+
+// Cancelled reports whether Cancel (or Close) stopped the job before it fired
+func (j *Job) Cancelled() bool {
+	j.s.mu.Lock()
+	defer j.s.mu.Unlock()
+	return j.cancelled
+}
+
+// jobHeap is a min-heap ordered by due time, so the next job is always jobs[0]
+type jobHeap []*Job
+
+func (h jobHeap) Len() int           { return len(h) }
+func (h jobHeap) Less(i, j int) bool { return h[i].At.Before(h[j].At) }
+func (h jobHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *jobHeap) Push(x any) {
+	job := x.(*Job)
+	job.index = len(*h)
+	*h = append(*h, job)
+}
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	job := old[len(old)-1]
+	old[len(old)-1] = nil
+	job.index = -1
+	*h = old[:len(old)-1]
+	return job
+}
+
+// Scheduler sends notifications at a given time. A single goroutine sleeps
+// until the earliest job is due; scheduling or cancelling wakes it up to re-check.
+type Scheduler struct {
+	mu     sync.Mutex
+	jobs   jobHeap
+	closed bool
+
+	wake  chan struct{}
+	stop  chan struct{}
+	loop  sync.WaitGroup
+	sends sync.WaitGroup
+}
+
+func New() *Scheduler {
+	s := &Scheduler{wake: make(chan struct{}, 1), stop: make(chan struct{})}
+	s.loop.Add(1)
+	go s.run()
+	return s
+}
+
+// ScheduleNotification sends message through notifier at the given time.
+// Times in the past fire immediately. After Close the job comes back already cancelled.
+func (s *Scheduler) ScheduleNotification(notifier notification.Notifier, message string, at time.Time) *Job {
+	job := &Job{At: at, notifier: notifier, message: message, s: s, index: -1, done: make(chan struct{})}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		job.cancelled = true
+		close(job.done)
+		return job
+	}
+	heap.Push(&s.jobs, job)
+	s.poke()
+	return job
+}
+
+var (
+	defaultOnce      sync.Once
+	defaultScheduler *Scheduler
+)
+
+// ScheduleNotification schedules on a shared scheduler started on first use.
+// It runs for the life of the process; use New for one you can Close.
+func ScheduleNotification(notifier notification.Notifier, message string, at time.Time) *Job {
+	defaultOnce.Do(func() { defaultScheduler = New() })
+	return defaultScheduler.ScheduleNotification(notifier, message, at)
+}
+
+// Pending is the number of jobs waiting to fire
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.jobs)
+}
+
+// Close cancels every pending job and waits for sends already in flight
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	for len(s.jobs) > 0 {
+		job := heap.Pop(&s.jobs).(*Job)
+		job.cancelled = true
+		close(job.done)
+	}
+	s.mu.Unlock()
+
+	close(s.stop)
+	s.loop.Wait()
+	s.sends.Wait()
+}
+
+// poke wakes the run loop without blocking; callers hold mu
+func (s *Scheduler) poke() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run() {
+	defer s.loop.Done()
+	for {
+		s.mu.Lock()
+		// fire everything that is due
+		for len(s.jobs) > 0 && !s.jobs[0].At.After(time.Now()) {
+			s.fire(heap.Pop(&s.jobs).(*Job))
+		}
+		var timer *time.Timer
+		var due <-chan time.Time
+		if len(s.jobs) > 0 {
+			timer = time.NewTimer(time.Until(s.jobs[0].At))
+			due = timer.C
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-due:
+		case <-s.wake:
+		case <-s.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// fire sends job in its own goroutine so a slow notifier doesn't delay the others; callers hold mu
+func (s *Scheduler) fire(job *Job) {
+	s.sends.Add(1)
+	go func() {
+		defer s.sends.Done()
+		err := job.notifier.Send(job.message)
+
+		s.mu.Lock()
+		job.err = err
+		s.mu.Unlock()
+		close(job.done)
+	}()
+}
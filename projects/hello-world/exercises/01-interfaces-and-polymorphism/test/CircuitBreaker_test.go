@@ -0,0 +1,91 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/breaker"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+// flakyNotifier fails while down is true and counts the calls that reached it
+type flakyNotifier struct {
+	down  bool
+	calls int
+}
+
+func (f *flakyNotifier) Send(message string) error {
+	return f.SendNotification(notification.Notification{Message: message})
+}
+
+func (f *flakyNotifier) SendNotification(n notification.Notification) error {
+	f.calls++
+	if f.down {
+		return errors.New("endpoint unavailable")
+	}
+	return nil
+}
+
+func (f *flakyNotifier) GetType() string {
+	return "Flaky Notifier"
+}
+
+func TestCircuitBreakerTripsAfterThreshold(t *testing.T) {
+	inner := &flakyNotifier{down: true}
+	cb := breaker.NewCircuitBreaker(inner, 3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if err := cb.Send("ping"); err == nil {
+			t.Fatal("Expected error from failing notifier")
+		}
+	}
+	if cb.State() != breaker.Open {
+		t.Fatalf("Expected open circuit, got %s", cb.State())
+	}
+
+	err := cb.Send("ping")
+	if !errors.Is(err, breaker.ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if inner.calls != 3 {
+		t.Errorf("Expected open circuit to skip the notifier, got %d calls", inner.calls)
+	}
+}
+
+func TestCircuitBreakerRecoversAfterCooldown(t *testing.T) {
+	inner := &flakyNotifier{down: true}
+	cb := breaker.NewCircuitBreaker(inner, 2, 20*time.Millisecond)
+
+	cb.Send("ping")
+	cb.Send("ping")
+	if cb.State() != breaker.Open {
+		t.Fatalf("Expected open circuit, got %s", cb.State())
+	}
+
+	inner.down = false
+	time.Sleep(30 * time.Millisecond)
+	if cb.State() != breaker.HalfOpen {
+		t.Fatalf("Expected half-open circuit after cooldown, got %s", cb.State())
+	}
+
+	if err := cb.Send("ping"); err != nil {
+		t.Fatalf("Expected probe to succeed, got %v", err)
+	}
+	if cb.State() != breaker.Closed {
+		t.Errorf("Expected closed circuit after successful probe, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreakerReopensOnFailedProbe(t *testing.T) {
+	inner := &flakyNotifier{down: true}
+	cb := breaker.NewCircuitBreaker(inner, 1, 20*time.Millisecond)
+
+	cb.Send("ping")
+	time.Sleep(30 * time.Millisecond)
+	cb.Send("ping")
+
+	if cb.State() != breaker.Open {
+		t.Errorf("Expected failed probe to reopen the circuit, got %s", cb.State())
+	}
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+func TestDeadLetterNotifierRecordsPermanentFailures(t *testing.T) {
+	inner := &flakyNotifier{down: true}
+	store := &notification.MemoryDeadLetterStore{}
+	dlq := notification.NewDeadLetterNotifier(inner, store, 2)
+
+	err := dlq.SendNotification(notification.Notification{Message: "Disk full", Severity: notification.SeverityCritical})
+
+	if err == nil {
+		t.Fatal("Expected the send error to be returned")
+	}
+	if inner.calls != 3 {
+		t.Errorf("Expected 1 attempt + 2 retries, got %d calls", inner.calls)
+	}
+	letters := store.Drain()
+	if len(letters) != 1 {
+		t.Fatalf("Expected 1 dead letter, got %d", len(letters))
+	}
+	letter := letters[0]
+	if letter.NotifierType != "Flaky Notifier" || letter.Notification.Message != "Disk full" {
+		t.Errorf("Expected Flaky Notifier / Disk full, got %s / %s", letter.NotifierType, letter.Notification.Message)
+	}
+	if letter.Err == nil || letter.FailedAt.IsZero() {
+		t.Errorf("Expected error and timestamp recorded, got %v at %v", letter.Err, letter.FailedAt)
+	}
+}
+
+func TestDeadLetterNotifierSkipsStoreOnRetrySuccess(t *testing.T) {
+	inner := &flakyNotifier{down: false}
+	store := &notification.MemoryDeadLetterStore{}
+
+	if err := notification.NewDeadLetterNotifier(inner, store, 2).Send("ok"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if store.Len() != 0 {
+		t.Errorf("Expected empty store, got %d letters", store.Len())
+	}
+}
+
+func TestDeadLetterReplay(t *testing.T) {
+	inner := &flakyNotifier{down: true}
+	store := &notification.MemoryDeadLetterStore{}
+	dlq := notification.NewDeadLetterNotifier(inner, store, 0)
+	dlq.Send("first")
+	dlq.Send("second")
+
+	resend := func(l notification.DeadLetter) error { return inner.SendNotification(l.Notification) }
+
+	// Still down: both letters are re-attempted and kept
+	if err := dlq.Replay(resend); err == nil {
+		t.Error("Expected replay errors while the endpoint is down")
+	}
+	if store.Len() != 2 || inner.calls != 4 {
+		t.Fatalf("Expected 2 letters kept after 4 calls, got %d letters and %d calls", store.Len(), inner.calls)
+	}
+
+	// Back up: replay delivers and empties the store
+	inner.down = false
+	var replayed []string
+	err := dlq.Replay(func(l notification.DeadLetter) error {
+		replayed = append(replayed, l.Notification.Message)
+		return resend(l)
+	})
+	if err != nil {
+		t.Fatalf("Expected replay to succeed, got %v", err)
+	}
+	if len(replayed) != 2 || replayed[0] != "first" || replayed[1] != "second" {
+		t.Errorf("Expected first and second replayed in order, got %v", replayed)
+	}
+	if store.Len() != 0 {
+		t.Errorf("Expected empty store, got %d letters", store.Len())
+	}
+}
+
+func TestDeadLetterReplayKeepsNewError(t *testing.T) {
+	store := &notification.MemoryDeadLetterStore{}
+	dlq := notification.NewDeadLetterNotifier(&flakyNotifier{down: true}, store, 0)
+	dlq.Send("ping")
+
+	errGone := errors.New("recipient gone")
+	if err := dlq.Replay(func(notification.DeadLetter) error { return errGone }); !errors.Is(err, errGone) {
+		t.Errorf("Expected replay error to wrap errGone, got %v", err)
+	}
+	if letters := store.Drain(); len(letters) != 1 || !errors.Is(letters[0].Err, errGone) {
+		t.Errorf("Expected the letter kept with the new error, got %+v", letters)
+	}
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/eventbus"
+)
+
+type alert struct {
+	Message string
+}
+
+func TestEventBusDeliversToAllSubscribers(t *testing.T) {
+	bus := eventbus.New[alert](10, eventbus.Block)
+
+	var mu sync.Mutex
+	received := make(map[int][]string)
+	for i := 0; i < 3; i++ {
+		id := i
+		bus.Subscribe(func(e alert) {
+			mu.Lock()
+			defer mu.Unlock()
+			received[id] = append(received[id], e.Message)
+		})
+	}
+
+	bus.Publish(alert{Message: "Server is down!"})
+	bus.Close()
+
+	for i := 0; i < 3; i++ {
+		if len(received[i]) != 1 || received[i][0] != "Server is down!" {
+			t.Errorf("Subscriber %d got %v", i, received[i])
+		}
+	}
+}
+
+func TestEventBusDropPolicyDiscardsWhenQueueFull(t *testing.T) {
+	bus := eventbus.New[alert](1, eventbus.Drop)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled []string
+	bus.Subscribe(func(e alert) {
+		if len(handled) == 0 {
+			close(started)
+			<-release
+		}
+		handled = append(handled, e.Message)
+	})
+
+	bus.Publish(alert{Message: "first"}) // picked up by the worker, which then blocks
+	<-started
+	bus.Publish(alert{Message: "second"}) // fills the queue
+	bus.Publish(alert{Message: "third"})  // queue full -> dropped
+
+	close(release)
+	bus.Close()
+
+	if bus.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", bus.Dropped())
+	}
+	if len(handled) != 2 || handled[1] != "second" {
+		t.Errorf("Expected first and second to be handled, got %v", handled)
+	}
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+func TestMultiNotifierJoinsErrorsAndKeepsSending(t *testing.T) {
+	var first, last bytes.Buffer
+	failing := &flakyNotifier{down: true}
+	multi := &notification.MultiNotifier{Notifiers: []notification.Notifier{
+		&notification.WriterNotifier{W: &first},
+		failing,
+		&notification.WriterNotifier{W: &last},
+	}}
+
+	err := multi.Send("Server is down!")
+
+	if err == nil {
+		t.Fatal("Expected joined error from failing child")
+	}
+	if !strings.Contains(err.Error(), "Flaky Notifier: endpoint unavailable") {
+		t.Errorf("Expected error to name the failing notifier, got %q", err)
+	}
+	if !strings.Contains(first.String(), "Server is down!") || !strings.Contains(last.String(), "Server is down!") {
+		t.Error("Expected the healthy notifiers to still receive the message")
+	}
+	if failing.calls != 1 {
+		t.Errorf("Expected failing notifier to be called once, got %d", failing.calls)
+	}
+}
+
+func TestMultiNotifierGetType(t *testing.T) {
+	multi := &notification.MultiNotifier{Notifiers: []notification.Notifier{
+		&notification.EmailNotifier{},
+		&notification.SMSNotifier{},
+	}}
+
+	if got := multi.GetType(); got != "Multi(Email Notifier, Sms Notifier)" {
+		t.Errorf("Unexpected type %q", got)
+	}
+}
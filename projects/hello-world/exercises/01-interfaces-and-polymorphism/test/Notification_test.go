@@ -0,0 +1,61 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+func TestSeverityFlowsToEachNotifier(t *testing.T) {
+	critical := notification.Notification{
+		Message:  "Server is down!",
+		Severity: notification.SeverityCritical,
+		Tags:     map[string]string{"env": "prod", "host": "api-1"},
+	}
+
+	tests := []struct {
+		name     string
+		notifier func(out *bytes.Buffer) notification.Notifier
+		expected []string
+	}{
+		{"email subject", func(out *bytes.Buffer) notification.Notifier {
+			return &notification.EmailNotifier{Sender: "alerts", Receiver: "ops", Out: out}
+		}, []string{"[CRITICAL] Notification", "Server is down!"}},
+		{"sms prefix", func(out *bytes.Buffer) notification.Notifier {
+			return &notification.SMSNotifier{PhoneNumber: "+1234567890", Out: out}
+		}, []string{"CRITICAL:", "Server is down!"}},
+		{"slack color", func(out *bytes.Buffer) notification.Notifier {
+			return &notification.SlackNotifier{Channel: "#alerts", Out: out}
+		}, []string{"color  danger", "env=prod host=api-1"}},
+		{"writer line", func(out *bytes.Buffer) notification.Notifier {
+			return &notification.WriterNotifier{W: out}
+		}, []string{"CRITICAL: Server is down! env=prod host=api-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := tt.notifier(&out).SendNotification(critical); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Expected %q in output %q", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestSendDefaultsToInfo(t *testing.T) {
+	var out bytes.Buffer
+	email := &notification.EmailNotifier{Sender: "alerts", Receiver: "ops", Out: &out}
+
+	email.Send("Deploy finished")
+
+	if !strings.Contains(out.String(), "[INFO] Notification") {
+		t.Errorf("Expected info subject, got %q", out.String())
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/metrics"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+func TestMeteredNotifierCountsResults(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := metrics.NewNotifierMetrics(reg)
+	if err != nil {
+		t.Fatalf("NewNotifierMetrics failed: %v", err)
+	}
+
+	inner := &flakyNotifier{}
+	n := m.Wrap(inner)
+	var _ notification.Notifier = n
+
+	if err := n.Send("ok 1"); err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	if err := n.SendNotification(notification.Notification{Message: "ok 2"}); err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	inner.down = true
+	if err := n.Send("lost"); err == nil {
+		t.Fatal("Expected the inner error to be returned")
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.Handler(reg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`notifications_sent_total{result="success",type="Flaky Notifier"} 2`,
+		`notifications_sent_total{result="failure",type="Flaky Notifier"} 1`,
+		`notification_send_duration_seconds_count{type="Flaky Notifier"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestNewNotifierMetricsRejectsDoubleRegistration(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := metrics.NewNotifierMetrics(reg); err != nil {
+		t.Fatalf("NewNotifierMetrics failed: %v", err)
+	}
+	if _, err := metrics.NewNotifierMetrics(reg); err == nil {
+		t.Error("Expected an error registering the same metrics twice")
+	}
+}
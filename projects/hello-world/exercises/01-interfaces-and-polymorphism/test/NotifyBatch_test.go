@@ -0,0 +1,61 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+func TestNotifyBatchSendsPerRecipientMessages(t *testing.T) {
+	var email, sms, writer bytes.Buffer
+	failing := &flakyNotifier{down: true}
+	recipients := []notification.Recipient{
+		{Notifier: &notification.EmailNotifier{Sender: "ops", Receiver: "alice", Out: &email}},
+		{Notifier: &notification.SMSNotifier{PhoneNumber: "+1555", Out: &sms}, Message: "VIP: 50% off today"},
+		{Notifier: &notification.WriterNotifier{W: &writer}},
+		{Notifier: failing},
+		{},
+	}
+
+	errs := notification.NotifyBatch(recipients, "Spring sale starts now")
+
+	if len(errs) != len(recipients) {
+		t.Fatalf("Expected %d errors, got %d", len(recipients), len(errs))
+	}
+	tests := []struct {
+		name    string
+		out     *bytes.Buffer
+		want    string
+		notWant string
+	}{
+		{"email gets default", &email, "Spring sale starts now", "VIP"},
+		{"sms gets override", &sms, "VIP: 50% off today", "Spring sale"},
+		{"writer gets default", &writer, "Spring sale starts now", "VIP"},
+	}
+	for i, tt := range tests {
+		if errs[i] != nil {
+			t.Errorf("%s: Expected no error, got %v", tt.name, errs[i])
+		}
+		if !strings.Contains(tt.out.String(), tt.want) || strings.Contains(tt.out.String(), tt.notWant) {
+			t.Errorf("%s: Expected %q (and not %q), got %q", tt.name, tt.want, tt.notWant, tt.out.String())
+		}
+	}
+
+	if errs[3] == nil || !strings.Contains(errs[3].Error(), "endpoint unavailable") {
+		t.Errorf("Expected the failing notifier's error at index 3, got %v", errs[3])
+	}
+	if failing.calls != 1 {
+		t.Errorf("Expected failing notifier to be called once, got %d", failing.calls)
+	}
+	if errs[4] == nil {
+		t.Error("Expected an error for a recipient without a notifier")
+	}
+}
+
+func TestNotifyBatchEmpty(t *testing.T) {
+	if errs := notification.NotifyBatch(nil, "hello"); len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+}
@@ -0,0 +1,157 @@
+package test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/scheduler"
+)
+
+// recordingNotifier is a goroutine-safe notifier that remembers what it sent and when
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []string
+	sentAt   []time.Time
+}
+
+func (r *recordingNotifier) Send(message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, message)
+	r.sentAt = append(r.sentAt, time.Now())
+	return nil
+}
+
+func (r *recordingNotifier) SendNotification(n notification.Notification) error {
+	return r.Send(n.Message)
+}
+
+func (r *recordingNotifier) GetType() string {
+	return "Recording Notifier"
+}
+
+func (r *recordingNotifier) sent() ([]string, []time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.messages...), append([]time.Time(nil), r.sentAt...)
+}
+
+// waitDone fails the test if job doesn't finish within a second
+func waitDone(t *testing.T, job *scheduler.Job) {
+	t.Helper()
+	select {
+	case <-job.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected job to finish within 1s")
+	}
+}
+
+func TestScheduleNotificationFiresAtTime(t *testing.T) {
+	s := scheduler.New()
+	defer s.Close()
+	rec := &recordingNotifier{}
+
+	at := time.Now().Add(50 * time.Millisecond)
+	job := s.ScheduleNotification(rec, "Stand-up in 5 minutes", at)
+	waitDone(t, job)
+
+	messages, sentAt := rec.sent()
+	if len(messages) != 1 || messages[0] != "Stand-up in 5 minutes" {
+		t.Fatalf("Expected one reminder, got %v", messages)
+	}
+	if sentAt[0].Before(at) {
+		t.Errorf("Expected send at or after %v, got %v", at, sentAt[0])
+	}
+	if job.Err() != nil || job.Cancelled() {
+		t.Errorf("Expected a clean send, got err %v cancelled %v", job.Err(), job.Cancelled())
+	}
+}
+
+func TestScheduleNotificationCancel(t *testing.T) {
+	s := scheduler.New()
+	defer s.Close()
+	rec := &recordingNotifier{}
+
+	job := s.ScheduleNotification(rec, "Never sent", time.Now().Add(time.Hour))
+	if !job.Cancel() {
+		t.Fatal("Expected Cancel on a pending job to return true")
+	}
+	waitDone(t, job)
+
+	if job.Cancel() {
+		t.Error("Expected a second Cancel to return false")
+	}
+	if !job.Cancelled() {
+		t.Error("Expected job to report cancelled")
+	}
+	if s.Pending() != 0 {
+		t.Errorf("Expected 0 pending jobs, got %d", s.Pending())
+	}
+	if messages, _ := rec.sent(); len(messages) != 0 {
+		t.Errorf("Expected nothing sent, got %v", messages)
+	}
+}
+
+func TestScheduleNotificationPastTimeFiresImmediately(t *testing.T) {
+	s := scheduler.New()
+	defer s.Close()
+	rec := &recordingNotifier{}
+
+	job := s.ScheduleNotification(rec, "Overdue", time.Now().Add(-time.Minute))
+	waitDone(t, job)
+
+	if messages, _ := rec.sent(); len(messages) != 1 {
+		t.Errorf("Expected overdue reminder sent, got %v", messages)
+	}
+}
+
+func TestScheduleNotificationFiresInTimeOrder(t *testing.T) {
+	s := scheduler.New()
+	defer s.Close()
+	rec := &recordingNotifier{}
+
+	now := time.Now()
+	third := s.ScheduleNotification(rec, "third", now.Add(90*time.Millisecond))
+	s.ScheduleNotification(rec, "first", now.Add(10*time.Millisecond))
+	s.ScheduleNotification(rec, "second", now.Add(50*time.Millisecond))
+	waitDone(t, third)
+
+	messages, _ := rec.sent()
+	want := []string{"first", "second", "third"}
+	if len(messages) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, messages)
+	}
+	for i := range want {
+		if messages[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, messages)
+			break
+		}
+	}
+}
+
+func TestSchedulerCloseCancelsPending(t *testing.T) {
+	s := scheduler.New()
+	job := s.ScheduleNotification(&recordingNotifier{}, "later", time.Now().Add(time.Hour))
+
+	s.Close()
+	waitDone(t, job)
+
+	if !job.Cancelled() {
+		t.Error("Expected Close to cancel the pending job")
+	}
+	if late := s.ScheduleNotification(&recordingNotifier{}, "after close", time.Now()); !late.Cancelled() {
+		t.Error("Expected jobs scheduled after Close to come back cancelled")
+	}
+}
+
+func TestPackageScheduleNotification(t *testing.T) {
+	rec := &recordingNotifier{}
+	job := scheduler.ScheduleNotification(rec, "default scheduler", time.Now().Add(10*time.Millisecond))
+	waitDone(t, job)
+
+	if messages, _ := rec.sent(); len(messages) != 1 {
+		t.Errorf("Expected one send through the default scheduler, got %v", messages)
+	}
+}
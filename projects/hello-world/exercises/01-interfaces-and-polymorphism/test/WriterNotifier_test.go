@@ -0,0 +1,46 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/01-interfaces-and-polymorphism/main/notification"
+)
+
+func TestWriterNotifierWritesLine(t *testing.T) {
+	var buf bytes.Buffer
+	var n notification.Notifier = &notification.WriterNotifier{W: &buf}
+
+	if err := n.Send("System Alert: Server is down!"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	line := strings.TrimSuffix(buf.String(), "\n")
+	timestamp, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		t.Fatalf("Unexpected output %q", buf.String())
+	}
+	if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+		t.Errorf("Expected RFC3339 timestamp, got %q", timestamp)
+	}
+	if rest != "[Writer Notifier] INFO: System Alert: Server is down!" {
+		t.Errorf("Unexpected message part %q", rest)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriterNotifierReturnsWriteError(t *testing.T) {
+	n := &notification.WriterNotifier{W: failingWriter{}}
+
+	if err := n.Send("hello"); err == nil {
+		t.Error("Expected write error to be returned")
+	}
+}
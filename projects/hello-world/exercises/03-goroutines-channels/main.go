@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/03-goroutines-channels/service"
@@ -56,5 +57,5 @@ func main() {
 		urls[index] = "http://www.google.com" + strconv.FormatInt(int64(index), 10)
 	}
 
-	responseFetcher.Fetch(urls)
+	responseFetcher.Fetch(context.Background(), urls)
 }
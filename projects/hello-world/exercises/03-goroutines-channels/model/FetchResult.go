@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
@@ -13,12 +14,20 @@ type Result struct {
 	Error      error
 }
 
-func FetchURL(url string, ch chan Result) Result {
+func FetchURL(ctx context.Context, url string, ch chan Result) Result {
 	fmt.Println("Fetching Response from url", url)
 
 	delay := rand.Intn(500)
 	delayDr := time.Duration(delay) * time.Millisecond
-	time.Sleep(delayDr)
+	startTime := time.Now()
+	select {
+	case <-time.After(delayDr):
+	case <-ctx.Done():
+		// the whole batch was cancelled, report it instead of finishing the "request"
+		result := Result{URL: url, Duration: time.Since(startTime), Error: ctx.Err()}
+		ch <- result
+		return result
+	}
 	result := Result{
 		URL:        url,
 		StatusCode: 200,
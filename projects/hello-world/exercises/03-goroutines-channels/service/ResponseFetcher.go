@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,28 +13,65 @@ var (
 )
 
 type ResponseFetcher interface {
-	Fetch(urls []string)
+	// Fetch runs the batch until every URL is done or ctx is cancelled
+	Fetch(ctx context.Context, urls []string) []model.Result
+	FetchWithProgress(ctx context.Context, urls []string) (<-chan ProgressEvent, <-chan []model.Result)
+}
+
+// ProgressEvent is emitted every time one more URL of the batch finishes
+type ProgressEvent struct {
+	Completed int
+	Total     int
 }
 
 type UrlResponseFetcher struct {
 }
 
-func (ur *UrlResponseFetcher) Fetch(urls []string) {
-	results := make(map[string]model.Result, len(urls))
+func (ur *UrlResponseFetcher) Fetch(ctx context.Context, urls []string) []model.Result {
+	results := make([]model.Result, 0, len(urls))
 
 	ch := make(chan model.Result)
 	startTime := time.Now()
 	for index := 0; index < len(urls); index++ {
-		go model.FetchURL(urls[index], ch)
+		go model.FetchURL(ctx, urls[index], ch)
 	}
+	// every worker sends exactly once (result or cancellation), so this never blocks forever
 	for index := 0; index < len(urls); index++ {
 		res := <-ch
-		results[res.URL] = res
+		results = append(results, res)
 		fmt.Println("Fetched result from URL", res.URL, " is : ", res)
 	}
 	endTime := time.Since(startTime)
 	fmt.Println("Total Time taken for batch request is ", endTime.Milliseconds())
-	return
+	return results
+}
+
+// FetchWithProgress starts the batch in the background and returns right away.
+// One ProgressEvent arrives per finished URL (the channel is closed afterwards),
+// then the full result list arrives on the second channel.
+func (ur *UrlResponseFetcher) FetchWithProgress(ctx context.Context, urls []string) (<-chan ProgressEvent, <-chan []model.Result) {
+	// buffered so a slow (or absent) progress reader never stalls the batch
+	progress := make(chan ProgressEvent, len(urls))
+	done := make(chan []model.Result, 1)
+
+	go func() {
+		defer close(progress)
+		defer close(done)
+
+		ch := make(chan model.Result)
+		for index := 0; index < len(urls); index++ {
+			go model.FetchURL(ctx, urls[index], ch)
+		}
+
+		results := make([]model.Result, 0, len(urls))
+		for index := 0; index < len(urls); index++ {
+			results = append(results, <-ch)
+			progress <- ProgressEvent{Completed: len(results), Total: len(urls)}
+		}
+		done <- results
+	}()
+
+	return progress, done
 }
 
 func GetResponseFetcherInstance() ResponseFetcher {
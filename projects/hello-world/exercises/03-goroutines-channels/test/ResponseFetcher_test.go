@@ -0,0 +1,64 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/03-goroutines-channels/service"
+)
+
+func TestFetchWithProgressEmitsOneEventPerURL(t *testing.T) {
+	fetcher := &service.UrlResponseFetcher{}
+	urls := []string{"http://a.com", "http://b.com", "http://c.com", "http://d.com"}
+
+	progress, done := fetcher.FetchWithProgress(context.Background(), urls)
+
+	events := 0
+	for event := range progress {
+		events++
+		if event.Completed != events || event.Total != len(urls) {
+			t.Errorf("Unexpected progress event %+v at position %d", event, events)
+		}
+	}
+	results := <-done
+
+	if events != len(urls) {
+		t.Errorf("Expected %d progress events, got %d", len(urls), events)
+	}
+	if len(results) != len(urls) {
+		t.Errorf("Expected %d results, got %d", len(urls), len(results))
+	}
+}
+
+func TestFetchStopsWhenBatchIsCancelled(t *testing.T) {
+	fetcher := &service.UrlResponseFetcher{}
+	urls := make([]string, 10)
+	for i := range urls {
+		urls[i] = "http://example.com/" + string(rune('a'+i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	results := fetcher.Fetch(ctx, urls)
+	elapsed := time.Since(start)
+
+	if len(results) != len(urls) {
+		t.Fatalf("Expected %d results, got %d", len(urls), len(results))
+	}
+	if elapsed > 300*time.Millisecond {
+		t.Errorf("Expected cancelled batch to return early, took %v", elapsed)
+	}
+	cancelled := 0
+	for _, res := range results {
+		if errors.Is(res.Error, context.Canceled) {
+			cancelled++
+		}
+	}
+	if cancelled == 0 {
+		t.Error("Expected at least one result to report cancellation")
+	}
+}
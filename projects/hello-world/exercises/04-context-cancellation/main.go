@@ -8,7 +8,7 @@ import (
 
 func main() {
 
-	rs := &service.ResponseFetcherImpl{}
+	rs := service.NewResponseFetcher(service.DefaultFetcherConfig())
 
 	urls := make([]string, 6)
 
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Sentinel errors for the common failure kinds, use errors.Is on Result.Error
+var (
+	ErrTimeout = errors.New("fetch timed out")
+	ErrDNS     = errors.New("dns lookup failed")
+)
+
+// HTTPStatusError is set on Result.Error for non-2xx responses, use errors.As to read the code
+type HTTPStatusError struct {
+	Code int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d %s", e.Code, http.StatusText(e.Code))
+}
+
+// ClassifyError wraps a transport error with ErrTimeout or ErrDNS when it matches.
+// The original error stays in the chain, so errors.Is(err, context.Canceled) still works.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsTimeout {
+			return fmt.Errorf("%w: %w", ErrTimeout, err)
+		}
+		return fmt.Errorf("%w: %w", ErrDNS, err)
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
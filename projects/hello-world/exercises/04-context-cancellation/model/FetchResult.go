@@ -1,9 +1,11 @@
 package model
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"math/rand"
+	"io"
+	"net/http"
 	"time"
 )
 
@@ -11,28 +13,69 @@ type Result struct {
 	URL        string
 	StatusCode int
 	Duration   time.Duration
+	Header     http.Header
 	Error      error
 }
 
-func FetchURL(ctx context.Context, url string, ch chan Result) Result {
+// FetchRequest describes the call FetchURL makes; an empty Method means GET
+type FetchRequest struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	Body    []byte
+}
+
+// GetURL is the convenience for a plain GET without headers
+func GetURL(ctx context.Context, client *http.Client, url string, ch chan Result) Result {
+	return FetchURL(ctx, client, FetchRequest{URL: url}, ch)
+}
+
+func FetchURL(ctx context.Context, client *http.Client, fr FetchRequest, ch chan Result) Result {
 	//fmt.Println("Fetching Response from url", url)
 	startTime := time.Now()
-	select {
-	case <-time.After(time.Duration(rand.Intn(100)) * time.Millisecond):
-		fmt.Println("working...")
-		result := Result{
-			URL:        url,
-			StatusCode: 200,
-			Duration:   time.Since(startTime),
-			Error:      nil,
-		}
+	url := fr.URL
+	result := Result{URL: url}
+
+	method := fr.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var body io.Reader
+	if fr.Body != nil {
+		body = bytes.NewReader(fr.Body)
+	}
+
+	// the request carries ctx, so cancelling ctx aborts the in-flight call
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		result.Error = err
+		result.Duration = time.Since(startTime)
 		ch <- result
-		fmt.Println("Response has been fetched for url", url)
 		return result
-	case <-ctx.Done():
-		fmt.Println("ctx cancelled, exiting worker for url", url)
-		res := Result{url, 500, time.Since(startTime), ctx.Err()}
-		ch <- res
-		return res
 	}
+	for key, value := range fr.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Println("request failed for url", url, ":", err)
+		result.Error = ClassifyError(err)
+		result.Duration = time.Since(startTime)
+		ch <- result
+		return result
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+
+	result.StatusCode = resp.StatusCode
+	result.Header = resp.Header
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		result.Error = &HTTPStatusError{Code: resp.StatusCode}
+	}
+	result.Duration = time.Since(startTime)
+	ch <- result
+	fmt.Println("Response has been fetched for url", url)
+	return result
 }
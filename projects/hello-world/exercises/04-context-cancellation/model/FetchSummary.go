@@ -0,0 +1,71 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// FetchSummary aggregates a batch of results for machine-readable output
+type FetchSummary struct {
+	Total       int
+	Succeeded   int
+	Failed      int
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	AvgDuration time.Duration
+	StatusCodes map[int]int // status code -> count, failed requests without a response are not counted
+}
+
+// Summarize computes counts and duration stats; a result succeeds when it has no error and a 2xx status
+func Summarize(results []Result) FetchSummary {
+	summary := FetchSummary{Total: len(results), StatusCodes: make(map[int]int)}
+	if len(results) == 0 {
+		return summary
+	}
+
+	var total time.Duration
+	summary.MinDuration = results[0].Duration
+	for _, res := range results {
+		if res.Error == nil && res.StatusCode >= 200 && res.StatusCode < 300 {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+		if res.StatusCode != 0 {
+			summary.StatusCodes[res.StatusCode]++
+		}
+
+		total += res.Duration
+		summary.MinDuration = min(summary.MinDuration, res.Duration)
+		summary.MaxDuration = max(summary.MaxDuration, res.Duration)
+	}
+	summary.AvgDuration = total / time.Duration(len(results))
+	return summary
+}
+
+// MarshalJSON writes durations as milliseconds and status codes as string keys
+func (s FetchSummary) MarshalJSON() ([]byte, error) {
+	codes := make(map[string]int, len(s.StatusCodes))
+	for code, count := range s.StatusCodes {
+		codes[strconv.Itoa(code)] = count
+	}
+
+	return json.Marshal(struct {
+		Total         int            `json:"total"`
+		Succeeded     int            `json:"succeeded"`
+		Failed        int            `json:"failed"`
+		MinDurationMs int64          `json:"min_duration_ms"`
+		MaxDurationMs int64          `json:"max_duration_ms"`
+		AvgDurationMs int64          `json:"avg_duration_ms"`
+		StatusCodes   map[string]int `json:"status_codes"`
+	}{
+		Total:         s.Total,
+		Succeeded:     s.Succeeded,
+		Failed:        s.Failed,
+		MinDurationMs: s.MinDuration.Milliseconds(),
+		MaxDurationMs: s.MaxDuration.Milliseconds(),
+		AvgDurationMs: s.AvgDuration.Milliseconds(),
+		StatusCodes:   codes,
+	})
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultTimeout         = 10 * time.Second
+	DefaultMaxIdleConns    = 100
+	DefaultMaxIdlePerHost  = 10
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// FetcherConfig holds the HTTP client used by the fetcher.
+// Inject your own client (e.g. with a short timeout) in tests.
+type FetcherConfig struct {
+	Client *http.Client
+
+	// CacheTTL enables the in-memory result cache when > 0
+	CacheTTL time.Duration
+}
+
+// DefaultFetcherConfig returns a client with a request timeout and a bounded
+// connection pool, unlike http.DefaultClient which can hang forever.
+func DefaultFetcherConfig() FetcherConfig {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = DefaultMaxIdleConns
+	transport.MaxIdleConnsPerHost = DefaultMaxIdlePerHost
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+
+	return FetcherConfig{
+		Client: &http.Client{
+			Timeout:   DefaultTimeout,
+			Transport: transport,
+		},
+	}
+}
+
+var defaultClient = DefaultFetcherConfig().Client
+
+func NewResponseFetcher(cfg FetcherConfig) *ResponseFetcherImpl {
+	if cfg.Client == nil {
+		cfg.Client = defaultClient
+	}
+	rf := &ResponseFetcherImpl{client: cfg.Client}
+	if cfg.CacheTTL > 0 {
+		rf.cache = newResultCache(cfg.CacheTTL)
+	}
+	return rf
+}
+
+// httpClient falls back to the default client for a zero-value ResponseFetcherImpl
+func (rf *ResponseFetcherImpl) httpClient() *http.Client {
+	if rf.client == nil {
+		return defaultClient
+	}
+	return rf.client
+}
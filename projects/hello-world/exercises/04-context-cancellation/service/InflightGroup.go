@@ -0,0 +1,44 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/04-context-cancellation/model"
+)
+
+type inflightCall struct {
+	done   chan struct{}
+	result model.Result
+}
+
+// inflightGroup collapses concurrent fetches of the same URL into one request,
+// like golang.org/x/sync/singleflight. The zero value is ready to use.
+type inflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+// do runs fn for url unless a call for url is already running,
+// in which case it waits for that call and returns its result
+func (g *inflightGroup) do(url string, fn func() model.Result) model.Result {
+	g.mu.Lock()
+	if call, ok := g.calls[url]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return call.result
+	}
+	if g.calls == nil {
+		g.calls = make(map[string]*inflightCall)
+	}
+	call := &inflightCall{done: make(chan struct{})}
+	g.calls[url] = call
+	g.mu.Unlock()
+
+	call.result = fn()
+
+	g.mu.Lock()
+	delete(g.calls, url)
+	g.mu.Unlock()
+	close(call.done)
+	return call.result
+}
@@ -4,27 +4,59 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/04-context-cancellation/model"
 )
 
 type ResponseFetcher interface {
-	FetchResp(url string)
+	// Fetch runs the batch until every URL is done or ctx is cancelled
+	Fetch(ctx context.Context, urls []string) []model.Result
+	FetchResp(url string) model.Result
 }
 
-type ResponseFetcherImpl struct{}
+type ResponseFetcherImpl struct {
+	client   *http.Client
+	cache    *resultCache
+	inflight inflightGroup
+}
 
-func (rf *ResponseFetcherImpl) FetchResp(url string) {
-	// do some work here;
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	ch := make(chan model.Result)
-	go model.FetchURL(ctx, url, ch)
+func (rf *ResponseFetcherImpl) FetchResp(url string) model.Result {
+	ctx, cancel := context.WithCancel(context.Background())
+	// cancel as soon as we return, not after some extra work
+	defer cancel()
+
+	// buffered so the worker can always deliver its single result and exit,
+	// even if nobody is receiving anymore
+	ch := make(chan model.Result, 1)
+	go rf.fetch(ctx, url, ch)
 	resp := <-ch
 	fmt.Println(resp)
-	time.Sleep(2 * time.Second)
-	defer cancel()
+	return resp
+}
+
+// fetch is model.GetURL with the optional cache in front of it.
+// Concurrent fetches of the same URL share one request; the caller that
+// started it decides the ctx, which is the batch ctx inside Fetch.
+func (rf *ResponseFetcherImpl) fetch(ctx context.Context, url string, ch chan model.Result) model.Result {
+	res := rf.inflight.do(url, func() model.Result { return rf.load(ctx, url) })
+	ch <- res
+	return res
+}
+
+func (rf *ResponseFetcherImpl) load(ctx context.Context, url string) model.Result {
+	if rf.cache == nil {
+		return model.GetURL(ctx, rf.httpClient(), url, make(chan model.Result, 1))
+	}
+	if res, ok := rf.cache.get(url); ok {
+		return res
+	}
+
+	res := model.GetURL(ctx, rf.httpClient(), url, make(chan model.Result, 1))
+	rf.cache.put(res)
+	return res
 }
 
 func (rf *ResponseFetcherImpl) SimulateContext(ctx context.Context) {
@@ -42,7 +74,7 @@ func (rf *ResponseFetcherImpl) FetchWithContextCancel(urls []string) {
 	for index := 0; index < len(urls); index++ {
 		ctx := context.Background()
 		ctx, cancel := context.WithCancel(ctx)
-		go model.FetchURL(ctx, urls[index], ch)
+		go rf.fetch(ctx, urls[index], ch)
 		delay := time.Duration(rand.Intn(200)) * time.Millisecond
 		fmt.Println("Simulating delay for index , ", index, " is : ", delay)
 		time.Sleep(delay)
@@ -56,14 +88,41 @@ func (rf *ResponseFetcherImpl) FetchWithContextCancel(urls []string) {
 }
 
 func (rf *ResponseFetcherImpl) FetchWithTimeOut(urls []string, timeOut int) {
-	ch := make(chan model.Result)
-	for index := 0; index < len(urls); index++ {
-		ctx := context.Background()
-		ctx, _ = context.WithTimeout(ctx, time.Duration(timeOut)*time.Millisecond)
-		go model.FetchURL(ctx, urls[index], ch)
-	}
-	for index := 0; index < len(urls); index++ {
-		res := <-ch
+	// all requests start together, so one batch deadline == one timeout per request
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeOut)*time.Millisecond)
+	defer cancel()
+	for _, res := range rf.Fetch(ctx, urls) {
 		fmt.Println("Received response", res)
 	}
 }
+
+// Fetch returns the results in the same order as urls.
+// Repeated URLs are fetched once and share the result.
+// Cancelling ctx aborts every in-flight request of the batch.
+func (rf *ResponseFetcherImpl) Fetch(ctx context.Context, urls []string) []model.Result {
+	// one slot per url, each goroutine only writes its own index -> no locking needed
+	slots := make([]model.Result, len(urls))
+
+	var wg sync.WaitGroup
+	for index, url := range urls {
+		wg.Add(1)
+		go func(index int, url string) {
+			defer wg.Done()
+			slots[index] = rf.fetch(ctx, url, make(chan model.Result, 1))
+		}(index, url)
+	}
+	wg.Wait()
+	return slots
+}
+
+// FetchAll fetches every url concurrently and keeps the URL -> result mapping,
+// including entries for fetches that were cancelled by ctx.
+func (rf *ResponseFetcherImpl) FetchAll(ctx context.Context, urls []string) map[string]model.Result {
+	slots := rf.Fetch(ctx, urls)
+
+	results := make(map[string]model.Result, len(urls))
+	for index, url := range urls {
+		results[url] = slots[index]
+	}
+	return results
+}
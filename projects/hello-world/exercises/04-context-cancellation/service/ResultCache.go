@@ -0,0 +1,54 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/04-context-cancellation/model"
+)
+
+type cacheEntry struct {
+	result    model.Result
+	expiresAt time.Time
+}
+
+// resultCache is a small in-memory URL -> Result cache with a fixed TTL
+type resultCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newResultCache(ttl time.Duration) *resultCache {
+	return &resultCache{ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+func (c *resultCache) get(url string) (model.Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[url]
+	if !ok {
+		return model.Result{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, url)
+		return model.Result{}, false
+	}
+	return entry.result, true
+}
+
+// put stores successful results, unless the server asked us not to cache them
+func (c *resultCache) put(res model.Result) {
+	if res.Error != nil || !isCacheable(res) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[res.URL] = cacheEntry{result: res, expiresAt: time.Now().Add(c.ttl)}
+}
+
+func isCacheable(res model.Result) bool {
+	cacheControl := strings.ToLower(res.Header.Get("Cache-Control"))
+	return !strings.Contains(cacheControl, "no-cache") && !strings.Contains(cacheControl, "no-store")
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/04-context-cancellation/model"
+)
+
+func TestFetchClassifiesTimeout(t *testing.T) {
+	srv := newSlowServer(t, 100*time.Millisecond)
+	client := &http.Client{Timeout: time.Millisecond}
+
+	res := model.GetURL(context.Background(), client, srv.URL, make(chan model.Result, 1))
+
+	if !errors.Is(res.Error, model.ErrTimeout) {
+		t.Errorf("Expected ErrTimeout, got %v", res.Error)
+	}
+}
+
+func TestFetchClassifiesNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	res := model.GetURL(context.Background(), srv.Client(), srv.URL, make(chan model.Result, 1))
+
+	var statusErr *model.HTTPStatusError
+	if !errors.As(res.Error, &statusErr) {
+		t.Fatalf("Expected HTTPStatusError, got %v", res.Error)
+	}
+	if statusErr.Code != http.StatusNotFound {
+		t.Errorf("Expected code 404, got %d", statusErr.Code)
+	}
+	if errors.Is(res.Error, model.ErrTimeout) || errors.Is(res.Error, model.ErrDNS) {
+		t.Errorf("404 should not be classified as timeout/dns: %v", res.Error)
+	}
+}
+
+func TestClassifyErrorDNS(t *testing.T) {
+	err := model.ClassifyError(&net.DNSError{Err: "no such host", Name: "nope.invalid", IsNotFound: true})
+
+	if !errors.Is(err, model.ErrDNS) {
+		t.Errorf("Expected ErrDNS, got %v", err)
+	}
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		t.Error("Expected the original *net.DNSError to stay in the chain")
+	}
+}
+
+func TestClassifyErrorKeepsCancellation(t *testing.T) {
+	err := model.ClassifyError(context.Canceled)
+
+	if !errors.Is(err, context.Canceled) || errors.Is(err, model.ErrTimeout) {
+		t.Errorf("Expected plain cancellation, got %v", err)
+	}
+}
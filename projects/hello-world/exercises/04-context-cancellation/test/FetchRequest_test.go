@@ -0,0 +1,59 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/04-context-cancellation/model"
+)
+
+// newEchoServer reflects the request method, auth header and body back as response headers
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Echo-Method", r.Method)
+		w.Header().Set("X-Echo-Authorization", r.Header.Get("Authorization"))
+		w.Header().Set("X-Echo-Body", string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchURLSendsMethodHeadersAndBody(t *testing.T) {
+	srv := newEchoServer(t)
+
+	res := model.FetchURL(context.Background(), srv.Client(), model.FetchRequest{
+		URL:     srv.URL,
+		Method:  http.MethodPost,
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+		Body:    []byte(`{"poll":true}`),
+	}, make(chan model.Result, 1))
+
+	if res.Error != nil {
+		t.Fatalf("Unexpected error: %v", res.Error)
+	}
+	if got := res.Header.Get("X-Echo-Method"); got != http.MethodPost {
+		t.Errorf("Expected POST, server saw %q", got)
+	}
+	if got := res.Header.Get("X-Echo-Authorization"); got != "Bearer secret" {
+		t.Errorf("Expected auth header, server saw %q", got)
+	}
+	if got := res.Header.Get("X-Echo-Body"); got != `{"poll":true}` {
+		t.Errorf("Expected body, server saw %q", got)
+	}
+}
+
+func TestGetURLDefaultsToGet(t *testing.T) {
+	srv := newEchoServer(t)
+
+	res := model.GetURL(context.Background(), srv.Client(), srv.URL, make(chan model.Result, 1))
+
+	if got := res.Header.Get("X-Echo-Method"); got != http.MethodGet {
+		t.Errorf("Expected GET, server saw %q", got)
+	}
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/04-context-cancellation/model"
+)
+
+func fixedResults() []model.Result {
+	return []model.Result{
+		{URL: "a", StatusCode: 200, Duration: 100 * time.Millisecond},
+		{URL: "b", StatusCode: 200, Duration: 300 * time.Millisecond},
+		{URL: "c", StatusCode: 404, Duration: 200 * time.Millisecond},
+		{URL: "d", Duration: 400 * time.Millisecond, Error: errors.New("timeout")},
+	}
+}
+
+func TestSummarizeMath(t *testing.T) {
+	summary := model.Summarize(fixedResults())
+
+	if summary.Total != 4 || summary.Succeeded != 2 || summary.Failed != 2 {
+		t.Errorf("Unexpected counts: %+v", summary)
+	}
+	if summary.MinDuration != 100*time.Millisecond {
+		t.Errorf("Expected min 100ms, got %v", summary.MinDuration)
+	}
+	if summary.MaxDuration != 400*time.Millisecond {
+		t.Errorf("Expected max 400ms, got %v", summary.MaxDuration)
+	}
+	if summary.AvgDuration != 250*time.Millisecond {
+		t.Errorf("Expected avg 250ms, got %v", summary.AvgDuration)
+	}
+	if summary.StatusCodes[200] != 2 || summary.StatusCodes[404] != 1 || len(summary.StatusCodes) != 2 {
+		t.Errorf("Unexpected status breakdown: %v", summary.StatusCodes)
+	}
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	summary := model.Summarize(nil)
+	if summary.Total != 0 || summary.AvgDuration != 0 {
+		t.Errorf("Expected zero summary, got %+v", summary)
+	}
+}
+
+func TestSummaryMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(model.Summarize(fixedResults()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid JSON %s: %v", data, err)
+	}
+	if decoded["avg_duration_ms"] != float64(250) {
+		t.Errorf("Expected avg_duration_ms 250, got %v", decoded["avg_duration_ms"])
+	}
+	codes := decoded["status_codes"].(map[string]any)
+	if codes["200"] != float64(2) {
+		t.Errorf("Expected two 200s, got %v", codes)
+	}
+}
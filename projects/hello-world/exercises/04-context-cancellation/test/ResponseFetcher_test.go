@@ -0,0 +1,180 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/04-context-cancellation/service"
+)
+
+// newSlowServer answers every request with 200 after the given delay
+func newSlowServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newFetcher returns a fetcher whose client doesn't keep idle connections around,
+// so goroutine counts go back to the baseline once requests finish
+func newFetcher(timeout time.Duration) *service.ResponseFetcherImpl {
+	return service.NewResponseFetcher(service.FetcherConfig{
+		Client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{DisableKeepAlives: true},
+		},
+	})
+}
+
+// waitForGoroutines polls until the goroutine count drops back to want (or the deadline passes)
+func waitForGoroutines(t *testing.T, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Leaked goroutines: have %d, want %d", runtime.NumGoroutine(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFetchRespReturnsResultWithoutLeaking(t *testing.T) {
+	srv := newSlowServer(t, 0)
+	rf := newFetcher(time.Second)
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 20; i++ {
+		res := rf.FetchResp(srv.URL)
+		if res.URL != srv.URL || res.StatusCode != 200 || res.Error != nil {
+			t.Fatalf("Unexpected result: %+v", res)
+		}
+	}
+
+	waitForGoroutines(t, before)
+}
+
+func TestFetchRespReturnsPromptly(t *testing.T) {
+	srv := newSlowServer(t, 0)
+	rf := newFetcher(time.Second)
+
+	start := time.Now()
+	rf.FetchResp(srv.URL)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("FetchResp took %v, expected it to return right after the result", elapsed)
+	}
+}
+
+func TestFetchAllReturnsEntryForEveryURL(t *testing.T) {
+	srv := newSlowServer(t, 0)
+	rf := newFetcher(time.Second)
+	urls := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c", srv.URL + "/d", srv.URL + "/e"}
+
+	results := rf.FetchAll(context.Background(), urls)
+
+	if len(results) != len(urls) {
+		t.Fatalf("Expected %d results, got %d", len(urls), len(results))
+	}
+	for _, url := range urls {
+		res, ok := results[url]
+		if !ok {
+			t.Errorf("Missing result for %s", url)
+			continue
+		}
+		if res.URL != url {
+			t.Errorf("Result for %s carries URL %s", url, res.URL)
+		}
+	}
+}
+
+func TestFetchAllKeepsCancelledEntries(t *testing.T) {
+	srv := newSlowServer(t, time.Second)
+	rf := newFetcher(5 * time.Second)
+	urls := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	results := rf.FetchAll(ctx, urls)
+
+	for _, url := range urls {
+		res, ok := results[url]
+		if !ok {
+			t.Errorf("Missing result for cancelled %s", url)
+			continue
+		}
+		if !errors.Is(res.Error, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded for %s, got %v", url, res.Error)
+		}
+	}
+}
+
+func TestInjectedClientTimeoutSurfacesAsError(t *testing.T) {
+	srv := newSlowServer(t, 100*time.Millisecond)
+	rf := newFetcher(time.Millisecond)
+
+	res := rf.FetchResp(srv.URL)
+
+	var netErr net.Error
+	if !errors.As(res.Error, &netErr) || !netErr.Timeout() {
+		t.Errorf("Expected a timeout error, got %v", res.Error)
+	}
+}
+
+func TestFetchCancelledMidBatch(t *testing.T) {
+	srv := newSlowServer(t, 2*time.Second)
+	rf := newFetcher(5 * time.Second)
+	urls := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(30*time.Millisecond, cancel)
+
+	start := time.Now()
+	results := rf.Fetch(ctx, urls)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected cancelled batch to return early, took %v", elapsed)
+	}
+	for i, res := range results {
+		if res.URL != urls[i] {
+			t.Errorf("Expected result %d for %s, got %s", i, urls[i], res.URL)
+		}
+		if !errors.Is(res.Error, context.Canceled) {
+			t.Errorf("Expected context.Canceled for %s, got %v", res.URL, res.Error)
+		}
+	}
+}
+
+func TestFetchDeduplicatesRepeatedURLs(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		time.Sleep(50 * time.Millisecond) // keep the first request in flight while the others start
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	rf := newFetcher(time.Second)
+
+	urls := []string{srv.URL, srv.URL, srv.URL, srv.URL}
+	results := rf.Fetch(context.Background(), urls)
+
+	if hits.Load() != 1 {
+		t.Errorf("Expected 1 server hit, got %d", hits.Load())
+	}
+	for i, res := range results {
+		if res.URL != srv.URL || res.StatusCode != 200 || res.Error != nil {
+			t.Errorf("Unexpected result at %d: %+v", i, res)
+		}
+	}
+}
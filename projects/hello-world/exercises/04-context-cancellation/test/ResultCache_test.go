@@ -0,0 +1,66 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/04-context-cancellation/service"
+)
+
+// newCountingServer counts the requests it receives and sets the given Cache-Control header
+func newCountingServer(t *testing.T, cacheControl string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestCachedFetcherHitsServerOnce(t *testing.T) {
+	srv, hits := newCountingServer(t, "")
+	rf := service.NewResponseFetcher(service.FetcherConfig{Client: srv.Client(), CacheTTL: time.Minute})
+
+	first := rf.FetchResp(srv.URL)
+	second := rf.FetchResp(srv.URL)
+
+	if hits.Load() != 1 {
+		t.Errorf("Expected 1 server hit, got %d", hits.Load())
+	}
+	if first.StatusCode != 200 || second.StatusCode != 200 {
+		t.Errorf("Unexpected results: %+v / %+v", first, second)
+	}
+}
+
+func TestCachedFetcherSkipsNoCacheResponses(t *testing.T) {
+	srv, hits := newCountingServer(t, "no-cache")
+	rf := service.NewResponseFetcher(service.FetcherConfig{Client: srv.Client(), CacheTTL: time.Minute})
+
+	rf.FetchResp(srv.URL)
+	rf.FetchResp(srv.URL)
+
+	if hits.Load() != 2 {
+		t.Errorf("Expected no-cache response to be fetched twice, got %d hits", hits.Load())
+	}
+}
+
+func TestCachedFetcherExpiresAfterTTL(t *testing.T) {
+	srv, hits := newCountingServer(t, "")
+	rf := service.NewResponseFetcher(service.FetcherConfig{Client: srv.Client(), CacheTTL: 10 * time.Millisecond})
+
+	rf.FetchResp(srv.URL)
+	time.Sleep(20 * time.Millisecond)
+	rf.FetchResp(srv.URL)
+
+	if hits.Load() != 2 {
+		t.Errorf("Expected expired entry to be refetched, got %d hits", hits.Load())
+	}
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/goccy/go-yaml"
+	"github.com/viraj/go-mono-repo/projects/hello-world/validation"
+)
+
+// =============================================================================
+// APPLICATION CONFIG
+// =============================================================================
+// In Java/Spring: @ConfigurationProperties bound from application.yml
+// Go: A YAML file decoded over the defaults, then validated once at startup
+//
+// A missing file is not an error: every setting has a default, so the file
+// only needs the keys a deployment wants to change.
+//
+//	pagination:
+//	  default_size: 20
+//	  max_size: 200
+
+// Config holds the tunable settings of the API
+type Config struct {
+	Pagination PaginationConfig `yaml:"pagination"`
+}
+
+// PaginationConfig bounds the page_size of paginated list endpoints
+// Java: spring.data.web.pageable.default-page-size / max-page-size
+type PaginationConfig struct {
+	DefaultSize int `yaml:"default_size"` // Used when page_size is missing or < 1
+	MaxSize     int `yaml:"max_size"`     // Larger page_size values are clamped to this
+}
+
+// Default returns the configuration used when no file overrides it
+func Default() Config {
+	return Config{
+		Pagination: DefaultPagination(),
+	}
+}
+
+// DefaultPagination is 10 per page, at most 100
+func DefaultPagination() PaginationConfig {
+	return PaginationConfig{DefaultSize: 10, MaxSize: 100}
+}
+
+// Load reads the YAML file at path over the defaults and validates the result
+// A missing file yields the defaults
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	return Parse(data, path)
+}
+
+// Parse decodes YAML data over the defaults and validates the result
+// name only labels errors (a file path, or the embedded file's name)
+func Parse(data []byte, name string) (*Config, error) {
+	cfg := Default()
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", name, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", name, err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks every section of the configuration
+func (c *Config) Validate() error {
+	if err := c.Pagination.Validate(); err != nil {
+		return fmt.Errorf("pagination: %w", err)
+	}
+	return nil
+}
+
+// Validate requires positive sizes with max_size >= default_size
+func (p PaginationConfig) Validate() error {
+	return validation.New().
+		Check("default_size", validation.Positive(p.DefaultSize)).
+		Check("max_size",
+			validation.Positive(p.MaxSize),
+			validation.Min(p.MaxSize, p.DefaultSize).WithMessage(fmt.Sprintf("must be >= default_size (%d)", p.DefaultSize))).
+		Err()
+}
+
+// PageSize turns a requested page size into the one to use:
+// DefaultSize when missing (< 1), at most MaxSize
+func (p PaginationConfig) PageSize(requested int) int {
+	switch {
+	case requested < 1:
+		return p.DefaultSize
+	case requested > p.MaxSize:
+		return p.MaxSize
+	default:
+		return requested
+	}
+}
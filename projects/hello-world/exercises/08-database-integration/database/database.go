@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -47,9 +48,35 @@ func Connect() error {
 func AutoMigrate() error {
 	log.Println("🔄 Running database migrations...")
 
+	// Data fixes the new constraints depend on (see migrations.go)
+	if err := backfillPublicIDs(); err != nil {
+		return err
+	}
+
 	// AutoMigrate creates tables, missing foreign keys, constraints, columns, indexes
 	// It WON'T delete unused columns (safe for production)
-	err := DB.AutoMigrate(
+	err := DB.AutoMigrate(append(models(), &SchemaMigration{})...)
+
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	if err := dropGlobalEmailIndex(); err != nil {
+		return err
+	}
+
+	// Like Flyway writing a row to flyway_schema_history
+	if err := RecordMigration(SchemaVersion); err != nil {
+		return err
+	}
+
+	log.Printf("✅ Database migration completed! (schema version %s)", SchemaVersion)
+	return nil
+}
+
+// Built-in entities, parents before children (FK order)
+// Other packages add theirs with RegisterModel (see registry.go)
+func init() {
+	RegisterModel(
 		// One-to-One
 		&model.User{},
 		&model.Profile{},
@@ -64,6 +91,7 @@ func AutoMigrate() error {
 		&model.Student{},
 		&model.Course{},
 		&model.Enrollment{},
+		&model.Waitlist{},
 
 		// Self-referential
 		&model.Category{},
@@ -71,13 +99,6 @@ func AutoMigrate() error {
 		// Polymorphic
 		&model.Comment{},
 	)
-
-	if err != nil {
-		return fmt.Errorf("failed to migrate database: %w", err)
-	}
-
-	log.Println("✅ Database migration completed!")
-	return nil
 }
 
 // GetDB returns the database instance
@@ -86,6 +107,26 @@ func GetDB() *gorm.DB {
 	return DB
 }
 
+// Ping checks the database connection is alive
+// Java: DataSourceHealthIndicator's validation query
+func Ping(ctx context.Context) error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Close closes the connection pool; call it last during shutdown
+// Java: HikariDataSource.close() when the context shuts down
+func Close() error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
 // =============================================================================
 // TRANSACTION HELPER
 // =============================================================================
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// DRIVER ERROR TRANSLATION
+// =============================================================================
+// In Java/Spring: SQLErrorCodeSQLExceptionTranslator turns vendor codes into
+// DataIntegrityViolationException / DuplicateKeyException
+// Go: drivers return their own error types, so match what each one reports
+
+// uniqueViolationMarkers are substrings each driver puts in a duplicate-key error
+var uniqueViolationMarkers = []string{
+	"UNIQUE constraint failed",                       // SQLite: UNIQUE constraint failed: users.email
+	"duplicate key value violates unique constraint", // Postgres (pq / pgx)
+	"SQLSTATE 23505",                                 // Postgres: pgx's code suffix
+	"Error 1062",                                     // MySQL: Error 1062 (23000): Duplicate entry ...
+	"Duplicate entry",                                // MySQL / MariaDB
+}
+
+// IsUniqueViolation reports whether err is a unique or primary key constraint violation
+// Matches gorm.ErrDuplicatedKey (with gorm.Config.TranslateError) and the raw
+// SQLite, Postgres and MySQL messages, so callers needn't import any driver
+func IsUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	msg := err.Error()
+	for _, marker := range uniqueViolationMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// CONNECTION POOL METRICS
+// =============================================================================
+// In Java/Spring: HikariCP's Micrometer metrics (hikaricp.connections.active, ...)
+// Go: Poll sql.DB.Stats() on a ticker and copy the numbers into gauges
+
+// PoolMetrics publishes sql.DB pool statistics as Prometheus gauges
+type PoolMetrics struct {
+	db       *gorm.DB
+	interval time.Duration
+
+	open  prometheus.Gauge
+	inUse prometheus.Gauge
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewPoolMetrics registers db_open_connections and db_in_use_connections with reg
+func NewPoolMetrics(db *gorm.DB, reg prometheus.Registerer, interval time.Duration) (*PoolMetrics, error) {
+	m := &PoolMetrics{
+		db:       db,
+		interval: interval,
+		open: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "db_open_connections",
+			Help: "Established connections to the database, both in use and idle.",
+		}),
+		inUse: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "db_in_use_connections",
+			Help: "Connections currently in use by queries or transactions.",
+		}),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	for _, c := range []prometheus.Collector{m.open, m.inUse} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Start samples the pool immediately, then every interval until Stop
+func (m *PoolMetrics) Start() {
+	m.started.Store(true)
+	m.collect()
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.collect()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the background sampling and waits for it to exit
+// Safe to call twice, and without Start (there is nothing to wait for then)
+func (m *PoolMetrics) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+		if m.started.Load() {
+			<-m.done
+		}
+	})
+}
+
+func (m *PoolMetrics) collect() {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return
+	}
+	stats := sqlDB.Stats()
+	m.open.Set(float64(stats.OpenConnections))
+	m.inUse.Set(float64(stats.InUse))
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// =============================================================================
+// SCHEMA VERSION TRACKING
+// =============================================================================
+// In Java/Spring: Flyway's flyway_schema_history table
+// Go: A tiny schema_migrations table (same name golang-migrate/Rails use)
+
+// SchemaVersion is the schema this build migrates to
+// Bump it whenever models change; versions must sort (zero-padded or timestamps)
+const SchemaVersion = "0008_tenant_email_unique"
+
+// SchemaMigration is one applied schema version
+type SchemaMigration struct {
+	Version   string    `gorm:"primaryKey;size:100" json:"version"`
+	AppliedAt time.Time `gorm:"not null" json:"applied_at"`
+}
+
+// TableName pins the table name so renaming the struct can't move the history
+// Java: @Table(name = "schema_migrations")
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// RecordMigration marks version as applied; recording the same version twice is a no-op
+func RecordMigration(version string) error {
+	m := SchemaMigration{Version: version, AppliedAt: time.Now()}
+	// INSERT ... ON CONFLICT DO NOTHING keeps the original applied_at
+	err := DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&m).Error
+	if err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", version, err)
+	}
+	return nil
+}
+
+// CurrentVersion returns the latest applied version, or "" if none was recorded
+func CurrentVersion() (string, error) {
+	var m SchemaMigration
+	err := DB.Order("version DESC").First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return m.Version, nil
+}
+
+// =============================================================================
+// DATA MIGRATIONS
+// =============================================================================
+// In Java/Spring: a Flyway V7__backfill_public_id.sql next to the DDL scripts
+// Go: Plain functions AutoMigrate runs before the schema changes that need them
+
+// backfillPublicIDs gives users created before 0005_user_public_id a UUID
+// Runs before AutoMigrate makes public_id NOT NULL: without it legacy users can't be
+// found by FindByPublicID, and saving two of them writes "" twice into the unique index
+// Idempotent, and a no-op on a fresh database
+func backfillPublicIDs() error {
+	migrator := DB.Migrator()
+	if !migrator.HasTable(&model.User{}) {
+		return nil
+	}
+	if !migrator.HasColumn(&model.User{}, "PublicID") {
+		// Add it nullable first; SQLite can't add a NOT NULL column to a table with rows
+		if err := DB.Exec("ALTER TABLE users ADD COLUMN public_id varchar(36)").Error; err != nil {
+			return fmt.Errorf("failed to add users.public_id: %w", err)
+		}
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := tx.Unscoped().Model(&model.User{}).
+			Where("public_id IS NULL OR public_id = ''").
+			Pluck("id", &ids).Error
+		if err != nil {
+			return fmt.Errorf("failed to find users without public_id: %w", err)
+		}
+		for _, id := range ids {
+			err := tx.Unscoped().Model(&model.User{}).
+				Where("id = ?", id).
+				UpdateColumn("public_id", uuid.NewString()).Error
+			if err != nil {
+				return fmt.Errorf("failed to backfill public_id for user %d: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// dropGlobalEmailIndex removes the pre-0008 UNIQUE (email) index
+// AutoMigrate adds UNIQUE (tenant_id, email) and a plain email index but never drops
+// indexes, and the old one would still stop two tenants from registering the same address
+func dropGlobalEmailIndex() error {
+	migrator := DB.Migrator()
+	if !migrator.HasIndex(&model.User{}, "idx_users_email") {
+		return nil
+	}
+	if err := migrator.DropIndex(&model.User{}, "idx_users_email"); err != nil {
+		return fmt.Errorf("failed to drop idx_users_email: %w", err)
+	}
+	return nil
+}
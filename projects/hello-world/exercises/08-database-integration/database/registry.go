@@ -0,0 +1,67 @@
+package database
+
+import (
+	"reflect"
+	"sync"
+)
+
+// =============================================================================
+// MODEL REGISTRY
+// =============================================================================
+// In Java/Spring: @EntityScan picks up every @Entity on the classpath
+// Go: No classpath scanning, so packages register their models in init()
+//
+//	func init() { database.RegisterModel(&Invoice{}) }
+
+var (
+	registryMu sync.Mutex
+	registry   []interface{}
+	registered = map[reflect.Type]bool{}
+)
+
+// RegisterModel adds models to the set AutoMigrate creates (and TruncateAll clears)
+// Models are migrated in registration order, so register parents before children
+// Registering the same type twice is a no-op
+func RegisterModel(models ...interface{}) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, m := range models {
+		t := reflect.TypeOf(m)
+		if registered[t] {
+			continue
+		}
+		registered[t] = true
+		registry = append(registry, m)
+	}
+}
+
+// models returns every registered entity in registration order (FK order)
+// Used by AutoMigrate, and in reverse by TruncateAll
+func models() []interface{} {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	return append([]interface{}(nil), registry...)
+}
+
+// UnregisterModel removes models from the set; unknown types are ignored
+// Mainly for tests that register a throwaway model and must not leak it into later tests
+func UnregisterModel(models ...interface{}) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, m := range models {
+		t := reflect.TypeOf(m)
+		if !registered[t] {
+			continue
+		}
+		delete(registered, t)
+		for i, r := range registry {
+			if reflect.TypeOf(r) == t {
+				registry = append(registry[:i:i], registry[i+1:]...)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// TEST RESET HELPER
+// =============================================================================
+// In Java/Spring: @Sql("/cleanup.sql") or Flyway's clean() (disabled by default in prod)
+// Go: Delete every row, children first, so each test starts from empty tables
+
+// ErrNotTestDatabase is returned when TruncateAll is pointed at a non-test database
+var ErrNotTestDatabase = errors.New("refusing to truncate a non-test database (set ALLOW_TRUNCATE=true to force)")
+
+// joinTables are many2many tables with no model of their own
+var joinTables = []string{"book_tags"}
+
+// TruncateAll permanently deletes all rows from every known table
+// Only runs against in-memory or test.db / *_test.db SQLite files unless ALLOW_TRUNCATE=true
+// Java equivalent: flyway.cleanDisabled=false
+func TruncateAll(db *gorm.DB) error {
+	if !isTestDatabase(db) && os.Getenv("ALLOW_TRUNCATE") != "true" {
+		return ErrNotTestDatabase
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range joinTables {
+			if err := tx.Exec("DELETE FROM " + table).Error; err != nil {
+				return fmt.Errorf("truncate %s: %w", table, err)
+			}
+		}
+
+		// Reverse of migration order = children before parents
+		// AllowGlobalUpdate: GORM blocks DELETE without WHERE by default
+		all := models()
+		for i := len(all) - 1; i >= 0; i-- {
+			err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(all[i]).Error
+			if err != nil {
+				return fmt.Errorf("truncate %T: %w", all[i], err)
+			}
+		}
+		return nil
+	})
+}
+
+// isTestDatabase recognises in-memory SQLite or a file named test.db, *_test.db or *.test.db
+// A plain substring match would also accept latest.db or contest.db
+func isTestDatabase(db *gorm.DB) bool {
+	dialector, ok := db.Dialector.(*sqlite.Dialector)
+	if !ok {
+		return false
+	}
+	dsn := strings.ToLower(dialector.DSN)
+	if strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory") {
+		return true
+	}
+	name, _, _ := strings.Cut(filepath.Base(dsn), "?")
+	return name == "test.db" || strings.HasSuffix(name, "_test.db") || strings.HasSuffix(name, ".test.db")
+}
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// =============================================================================
+// ZAP-BACKED GORM LOGGER
+// =============================================================================
+// In Java/Spring: Hibernate's org.hibernate.SQL + slow query log (hibernate.session.events.log.LOG_QUERIES_SLOWER_THAN_MS)
+// Go: Implement gorm's logger.Interface on top of zap
+//
+// Every query  -> debug (only in Info mode, like hibernate.show_sql)
+// Slow query   -> warn, with sql + duration
+// Failed query -> error (except record-not-found, which is a normal outcome)
+//
+// When the statement's context carries a request id (see ContextWithRequestID),
+// every line gets a request_id field, like Spring's MDC traceId in the SQL log.
+
+// ZapLogger sends GORM's logs through a structured zap logger
+type ZapLogger struct {
+	log           *zap.Logger
+	level         gormlogger.LogLevel
+	SlowThreshold time.Duration // 0 disables slow-query warnings
+}
+
+// NewZapLogger creates a GORM logger that warns on queries slower than slowThreshold
+func NewZapLogger(log *zap.Logger, slowThreshold time.Duration) *ZapLogger {
+	return &ZapLogger{
+		log:           log.With(zap.String("component", "gorm")),
+		level:         gormlogger.Warn,
+		SlowThreshold: slowThreshold,
+	}
+}
+
+// UseZapLogger replaces the default stdout logger on DB
+// Call after Connect()
+func UseZapLogger(log *zap.Logger, slowThreshold time.Duration) {
+	DB.Logger = NewZapLogger(log, slowThreshold).LogMode(gormlogger.Info)
+}
+
+// requestIDKey is the context key for the request id; unexported so only this package can set it
+type requestIDKey struct{}
+
+// ContextWithRequestID returns ctx tagged with the request id of the HTTP request it serves
+// Queries run via db.WithContext(ctx) are then logged with request_id
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request id stored by ContextWithRequestID, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// logger returns l.log, tagged with the request id from ctx when there is one
+func (l *ZapLogger) logger(ctx context.Context) *zap.Logger {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return l.log.With(zap.String("request_id", id))
+	}
+	return l.log
+}
+
+func (l *ZapLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *ZapLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.logger(ctx).Info(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *ZapLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.logger(ctx).Warn(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *ZapLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.logger(ctx).Error(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace is called by GORM after every statement
+func (l *ZapLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	log := l.logger(ctx)
+	fields := []zap.Field{
+		zap.String("sql", sql),
+		zap.Duration("duration", elapsed),
+		zap.Int64("rows", rows),
+	}
+
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		log.Error("query failed", append(fields, zap.Error(err))...)
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold && l.level >= gormlogger.Warn:
+		log.Warn("slow query", append(fields, zap.Duration("threshold", l.SlowThreshold))...)
+	case l.level >= gormlogger.Info:
+		log.Debug("query", fields...)
+	}
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+)
+
+// =============================================================================
+// ADMIN HANDLER - Destructive user operations
+// =============================================================================
+// In Java/Spring: @RestController @RequestMapping("/api/admin") @PreAuthorize("hasRole('ADMIN')")
+
+// AdminHandler exposes admin-only endpoints
+type AdminHandler struct {
+	service service.UserService
+	stats   repository.StatsRepository
+}
+
+// NewAdminHandler creates an AdminHandler with injected service and stats source
+func NewAdminHandler(service service.UserService, stats repository.StatsRepository) *AdminHandler {
+	return &AdminHandler{service: service, stats: stats}
+}
+
+// RegisterRoutes sets up admin routes behind auth (which must set the principal)
+// Usage: h.RegisterRoutes(r, middleware.TokenAuth(tokens))
+func (h *AdminHandler) RegisterRoutes(r *gin.Engine, auth gin.HandlerFunc) {
+	admin := r.Group("/api/admin", auth, middleware.RequireRole("admin"))
+	{
+		admin.DELETE("/users/:id", h.DeleteUser)       // DELETE /api/admin/users/:id?purge=true
+		admin.POST("/users/purge", h.PurgeDeleted)     // POST /api/admin/users/purge
+		admin.POST("/users/bulk-delete", h.BulkDelete) // POST /api/admin/users/bulk-delete
+		admin.PUT("/users/:id/role", h.ChangeRole)     // PUT /api/admin/users/:id/role
+		admin.GET("/stats", h.Stats)                   // GET /api/admin/stats
+	}
+}
+
+// PurgeRequest is the DTO for POST /api/admin/users/purge
+type PurgeRequest struct {
+	OlderThanDays int `json:"older_than_days" binding:"required,min=1"`
+}
+
+// PurgeResponse reports how many users were permanently removed
+type PurgeResponse struct {
+	Purged int64 `json:"purged"`
+}
+
+// BulkDeleteRequest is the DTO for POST /api/admin/users/bulk-delete
+type BulkDeleteRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=1000"`
+}
+
+// BulkDeleteResponse reports how many users were soft-deleted and which ids matched nobody
+type BulkDeleteResponse struct {
+	Deleted  int64  `json:"deleted"`
+	NotFound []uint `json:"not_found"`
+}
+
+// ChangeRoleRequest is the DTO for PUT /api/admin/users/:id/role
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// DeleteUser handles DELETE /api/admin/users/:id
+// ?purge=true removes the row for good; otherwise it's a normal soft delete
+func (h *AdminHandler) DeleteUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		fail(c, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	if c.Query("purge") == "true" {
+		err = h.service.HardDelete(uint(id))
+	} else {
+		err = h.service.Delete(uint(id))
+	}
+	if errors.Is(err, repository.ErrUserNotFound) {
+		fail(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		fail(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PurgeDeleted handles POST /api/admin/users/purge
+// Body: {"older_than_days": 30}
+func (h *AdminHandler) PurgeDeleted(c *gin.Context) {
+	var req PurgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, err)
+		return
+	}
+
+	purged, err := h.service.PurgeDeleted(time.Duration(req.OlderThanDays) * 24 * time.Hour)
+	if err != nil {
+		fail(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	ok(c, PurgeResponse{Purged: purged})
+}
+
+// BulkDelete handles POST /api/admin/users/bulk-delete
+// Body: {"ids": [1, 2, 3]}; all found users are soft-deleted in one transaction
+func (h *AdminHandler) BulkDelete(c *gin.Context) {
+	var req BulkDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, err)
+		return
+	}
+
+	deleted, notFound, err := h.service.BulkDelete(req.IDs)
+	if err != nil {
+		fail(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	ok(c, BulkDeleteResponse{Deleted: deleted, NotFound: notFound})
+}
+
+// ChangeRole handles PUT /api/admin/users/:id/role
+// Body: {"role": "admin"}; the role must be one of model.Roles
+func (h *AdminHandler) ChangeRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		fail(c, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	var req ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.service.ChangeRole(uint(id), req.Role)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, repository.ErrUserNotFound) {
+			status = http.StatusNotFound
+		}
+		fail(c, status, err)
+		return
+	}
+
+	ok(c, user)
+}
+
+// Stats handles GET /api/admin/stats
+// Live and soft-deleted row counts for users, books, courses, enrollments and posts
+func (h *AdminHandler) Stats(c *gin.Context) {
+	stats, err := h.stats.Counts(c.Request.Context())
+	if err != nil {
+		fail(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	ok(c, stats)
+}
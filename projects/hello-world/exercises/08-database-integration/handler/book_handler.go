@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+)
+
+// =============================================================================
+// BOOK HANDLER - HTTP Layer (Controller)
+// =============================================================================
+// In Java/Spring: @RestController @RequestMapping("/api/books")
+
+// BookHandler handles HTTP requests for book operations
+type BookHandler struct {
+	service service.BookService
+}
+
+// NewBookHandler creates a BookHandler with injected service
+func NewBookHandler(service service.BookService) *BookHandler {
+	return &BookHandler{service: service}
+}
+
+// RegisterRoutes sets up routes for book endpoints
+func (h *BookHandler) RegisterRoutes(r *gin.Engine) {
+	books := r.Group("/api/books")
+	{
+		books.PUT("/:id/tags", h.SetTags)    // PUT /api/books/:id/tags
+		books.GET("/:id/related", h.Related) // GET /api/books/:id/related?limit=5
+	}
+}
+
+// errInvalidBookID is the 400 for a non-numeric :id
+var errInvalidBookID = errors.New("invalid book id")
+
+// SetTagsRequest is the DTO for PUT /api/books/:id/tags
+// "tags": [] is allowed and clears the book's tags; a missing field is not
+type SetTagsRequest struct {
+	Tags []string `json:"tags" binding:"required"`
+}
+
+// SetTags handles PUT /api/books/:id/tags
+// Body: {"tags": ["go", "databases"]}; responds with the book's resulting tags
+func (h *BookHandler) SetTags(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		fail(c, http.StatusBadRequest, errInvalidBookID)
+		return
+	}
+
+	var req SetTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, err)
+		return
+	}
+
+	tags, err := h.service.SetTags(uint(id), req.Tags)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, repository.ErrBookNotFound) {
+			status = http.StatusNotFound
+		}
+		fail(c, status, err)
+		return
+	}
+
+	ok(c, tags)
+}
+
+// Related handles GET /api/books/:id/related
+// Other books ranked by how many tags they share with this one
+func (h *BookHandler) Related(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		fail(c, http.StatusBadRequest, errInvalidBookID)
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit")) // 0 (missing/invalid) means the default
+
+	related, err := h.service.RelatedBooks(uint(id), limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, repository.ErrBookNotFound) {
+			status = http.StatusNotFound
+		}
+		fail(c, status, err)
+		return
+	}
+
+	ok(c, related)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// =============================================================================
+// HEALTH CHECKS
+// =============================================================================
+// In Java/Spring: Actuator's /actuator/health aggregating HealthIndicator beans
+// Go/Gin: A registry of named check functions run concurrently per request
+//
+// Overall status is UP only if every check passes; any failure (or a check
+// exceeding the timeout) makes it DOWN and the endpoint returns 503.
+
+// Health statuses, same vocabulary as Spring Actuator
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+)
+
+// CheckFunc reports a subsystem's health; it should honour ctx cancellation
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of one named check
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// HealthReport is the /health response body
+type HealthReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// HealthChecker is a registry of named health checks
+type HealthChecker struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]CheckFunc
+}
+
+// NewHealthChecker creates an empty registry; each check run is bounded by timeout
+func NewHealthChecker(timeout time.Duration) *HealthChecker {
+	return &HealthChecker{timeout: timeout, checks: make(map[string]CheckFunc)}
+}
+
+// Register adds (or replaces) the check called name
+// Java: declaring a @Component implementing HealthIndicator
+func (h *HealthChecker) Register(name string, check CheckFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// Names returns the registered check names, sorted
+func (h *HealthChecker) Names() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run executes all checks concurrently and aggregates their results
+func (h *HealthChecker) Run(ctx context.Context) HealthReport {
+	h.mu.RLock()
+	checks := make(map[string]CheckFunc, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]CheckResult, len(checks))
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check CheckFunc) {
+			defer wg.Done()
+			result := runCheck(ctx, check)
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	report := HealthReport{Status: StatusUp, Checks: results}
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// runCheck runs one check, giving up when ctx expires even if the check doesn't
+func runCheck(ctx context.Context, check CheckFunc) CheckResult {
+	start := time.Now()
+	done := make(chan error, 1) // Buffered so a stuck check's goroutine can still exit
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = errors.New("check timed out")
+	}
+
+	result := CheckResult{Status: StatusUp, Duration: time.Since(start).String()}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Health handles GET /health: 200 when everything is UP, 503 otherwise
+func (h *HealthChecker) Health(c *gin.Context) {
+	report := h.Run(c.Request.Context())
+	status := http.StatusOK
+	if report.Status != StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// Live handles GET /livez: the process is up and serving, nothing else
+// Kubernetes restarts the pod when this fails, so it must not depend on the DB:
+// a database outage would otherwise turn into a restart loop
+// Java: Actuator's /actuator/health/liveness
+func (h *HealthChecker) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": StatusUp})
+}
+
+// Ready handles GET /readyz: can this instance take traffic right now?
+// Kubernetes stops routing to the pod while this returns 503
+// Java: Actuator's /actuator/health/readiness
+func (h *HealthChecker) Ready(c *gin.Context) {
+	h.Health(c)
+}
+
+// RegisterRoutes sets up the health endpoints
+func (h *HealthChecker) RegisterRoutes(r *gin.Engine) {
+	r.GET("/health", h.Health) // GET /health
+	r.GET("/livez", h.Live)    // GET /livez
+	r.GET("/readyz", h.Ready)  // GET /readyz
+}
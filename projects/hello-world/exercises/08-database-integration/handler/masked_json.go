@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// =============================================================================
+// MASKED JSON RESPONSES
+// =============================================================================
+// In Java/Spring: @JsonIgnore / @JsonProperty(access = WRITE_ONLY) on entity fields
+// Go/Gin: Tag sensitive fields with mask:"true" and render through MaskedJSON
+//
+// Unlike json:"-", the field still round-trips through encoding/json elsewhere
+// (caches, queues, internal APIs); it's only dropped from API responses.
+//
+//	type Account struct {
+//	    Email        string `json:"email"`
+//	    PasswordHash string `json:"password_hash" mask:"true"` // never sent to clients
+//	}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// MaskedJSON is c.JSON with every mask:"true" field removed
+func MaskedJSON(c *gin.Context, status int, v interface{}) {
+	c.JSON(status, Sanitize(v))
+}
+
+// Sanitize converts v into maps/slices shaped like its JSON form, minus masked fields
+// Types with their own MarshalJSON/MarshalText (time.Time, gorm.DeletedAt) are kept as-is
+func Sanitize(v interface{}) interface{} {
+	return sanitize(reflect.ValueOf(v))
+}
+
+func sanitize(rv reflect.Value) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+	if hasCustomMarshaler(rv.Type()) {
+		return rv.Interface()
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return sanitize(rv.Elem())
+	case reflect.Struct:
+		out := make(map[string]interface{})
+		sanitizeFields(rv, out)
+		return out
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Interface() // []byte encodes as base64, leave it alone
+		}
+		fallthrough
+	case reflect.Array:
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			out[i] = sanitize(rv.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		out := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = sanitize(iter.Value())
+		}
+		return out
+	default:
+		return rv.Interface()
+	}
+}
+
+// sanitizeFields copies rv's fields into out using encoding/json's naming rules
+// Embedded structs (like gorm.Model) are flattened; outer fields win on name clashes
+func sanitizeFields(rv reflect.Value, out map[string]interface{}) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Tag.Get("mask") == "true" {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := rv.Field(i)
+
+		if field.Anonymous && name == "" {
+			if embedded, ok := embeddedStruct(fv); ok {
+				promoted := make(map[string]interface{})
+				sanitizeFields(embedded, promoted)
+				for k, v := range promoted {
+					if _, exists := out[k]; !exists {
+						out[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "omitempty") && isEmptyValue(fv) {
+			continue
+		}
+		out[name] = sanitize(fv)
+	}
+}
+
+// embeddedStruct unwraps an embedded struct (or non-nil pointer to one) to flatten
+func embeddedStruct(fv reflect.Value) (reflect.Value, bool) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return reflect.Value{}, false
+		}
+		fv = fv.Elem()
+	}
+	if fv.Kind() != reflect.Struct || hasCustomMarshaler(fv.Type()) {
+		return reflect.Value{}, false
+	}
+	return fv, true
+}
+
+func hasCustomMarshaler(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return false
+	}
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)
+}
+
+// isEmptyValue mirrors encoding/json's omitempty rules (structs are never empty)
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
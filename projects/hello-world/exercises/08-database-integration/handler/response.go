@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/response"
+)
+
+// =============================================================================
+// API RESPONSE HELPERS
+// =============================================================================
+// In Java/Spring: ResponseEntity.ok(ApiResponse.of(...)) in every controller
+// Go/Gin: ok/fail helpers every handler goes through
+//
+// The envelope itself lives in the response package (see response.go there),
+// shared with the middleware. Here data is also run through Sanitize.
+
+// ok responds 200 with data in the envelope, minus mask:"true" fields
+func ok(c *gin.Context, data any) {
+	respond(c, http.StatusOK, data, nil)
+}
+
+// created responds 201 with the new resource in the envelope
+func created(c *gin.Context, data any) {
+	respond(c, http.StatusCreated, data, nil)
+}
+
+// okPage responds 200 with one page of results and its PageMeta
+func okPage(c *gin.Context, data any, meta response.PageMeta) {
+	respond(c, http.StatusOK, data, meta)
+}
+
+// fail responds with status and err's message in the envelope
+func fail(c *gin.Context, status int, err error) {
+	response.Fail(c, status, err)
+}
+
+func respond(c *gin.Context, status int, data, meta any) {
+	c.JSON(status, response.APIResponse{Data: Sanitize(data), Meta: meta})
+}
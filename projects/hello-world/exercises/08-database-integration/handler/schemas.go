@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+)
+
+// =============================================================================
+// REQUEST SCHEMAS
+// =============================================================================
+// JSON Schemas checked by middleware.ValidateJSON before the handler runs
+// Java: the schema files you'd keep under src/main/resources/schemas
+
+// RegisterUserSchema describes the body of POST /api/users
+const RegisterUserSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"properties": {
+		"name":  {"type": "string", "minLength": 2, "maxLength": 100},
+		"email": {"type": "string", "format": "email"},
+		"age":   {"type": "integer", "minimum": 0, "maximum": 150}
+	},
+	"required": ["name", "email"],
+	"additionalProperties": false
+}`
+
+var registerUserSchema = middleware.MustCompileSchema("register_user.json", RegisterUserSchema)
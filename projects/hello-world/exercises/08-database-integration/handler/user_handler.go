@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/querybuilder"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/response"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
 )
 
@@ -17,6 +24,7 @@ import (
 // UserHandler handles HTTP requests for user operations
 type UserHandler struct {
 	service service.UserService
+	cache   *middleware.ResponseCache // optional: caches list/search responses
 }
 
 // NewUserHandler creates a UserHandler with injected service
@@ -25,6 +33,21 @@ func NewUserHandler(service service.UserService) *UserHandler {
 	return &UserHandler{service: service}
 }
 
+// UseCache caches GET list/search responses in cache
+// Call before RegisterRoutes; the service should invalidate it on writes
+func (h *UserHandler) UseCache(cache *middleware.ResponseCache) *UserHandler {
+	h.cache = cache
+	return h
+}
+
+// cached prepends the cache middleware to handler when caching is enabled
+func (h *UserHandler) cached(handler gin.HandlerFunc) []gin.HandlerFunc {
+	if h.cache == nil {
+		return []gin.HandlerFunc{handler}
+	}
+	return []gin.HandlerFunc{h.cache.Middleware(), handler}
+}
+
 // RegisterRoutes sets up routes for user endpoints
 // Java equivalent: @RequestMapping annotations on methods
 func (h *UserHandler) RegisterRoutes(r *gin.Engine) {
@@ -32,13 +55,14 @@ func (h *UserHandler) RegisterRoutes(r *gin.Engine) {
 	// Java: @RequestMapping("/api/users") on class
 	users := r.Group("/api/users")
 	{
-		users.POST("", h.Register)           // POST /api/users
-		users.GET("", h.GetAll)              // GET /api/users
-		users.GET("/:id", h.GetByID)         // GET /api/users/:id
-		users.PUT("/:id", h.Update)          // PUT /api/users/:id
-		users.DELETE("/:id", h.Delete)       // DELETE /api/users/:id
-		users.GET("/search", h.Search)       // GET /api/users/search?q=xxx
-		users.PUT("/:id/profile", h.UpdateProfile) // PUT /api/users/:id/profile
+		users.POST("", middleware.ValidateJSON(registerUserSchema), h.Register) // POST /api/users
+		users.GET("", h.cached(h.GetAll)...)                                    // GET /api/users
+		users.GET("/:id", h.GetByID)                                            // GET /api/users/:id
+		users.PUT("/:id", h.Update)                                             // PUT /api/users/:id
+		users.DELETE("/:id", h.Delete)                                          // DELETE /api/users/:id
+		users.GET("/search", h.cached(h.Search)...)                             // GET /api/users/search?q=xxx
+		users.PUT("/:id/profile", h.UpdateProfile)                              // PUT /api/users/:id/profile
+		users.GET("/:id/stats", h.Stats)                                        // GET /api/users/:id/stats
 	}
 }
 
@@ -72,18 +96,27 @@ type UserResponse struct {
 	Age   int    `json:"age"`
 }
 
-// PaginatedResponse wraps paginated results
-type PaginatedResponse struct {
-	Data       interface{} `json:"data"`
-	Page       int         `json:"page"`
-	PageSize   int         `json:"page_size"`
-	TotalCount int64       `json:"total_count"`
-	TotalPages int         `json:"total_pages"`
-}
+// errInvalidUserID is the 400 for a non-numeric :id
+var errInvalidUserID = errors.New("invalid user id")
+
+// maxBatchIDs caps GET /api/users?ids=... so one request can't ask for the whole table
+const maxBatchIDs = 100
 
-// ErrorResponse is the standard error response
-type ErrorResponse struct {
-	Error string `json:"error"`
+// parseIDs parses a comma-separated id list such as "1,2,3"
+func parseIDs(raw string) ([]uint, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxBatchIDs {
+		return nil, fmt.Errorf("at most %d ids per request, got %d", maxBatchIDs, len(parts))
+	}
+	ids := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", errInvalidUserID, part)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
 }
 
 // =============================================================================
@@ -98,7 +131,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 	// Bind and validate JSON body
 	// Like @RequestBody @Valid in Spring
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -107,49 +140,71 @@ func (h *UserHandler) Register(c *gin.Context) {
 	if err != nil {
 		// Determine status code based on error type
 		// In Spring, you'd use @ExceptionHandler or throw specific exceptions
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrConflict) {
+			status = http.StatusConflict
+		}
+		fail(c, status, err)
 		return
 	}
 
 	// Return created user
 	// 201 Created with the new resource
-	c.JSON(http.StatusCreated, user)
+	created(c, user)
 }
 
 // GetAll handles GET /api/users
 // Java: @GetMapping public ResponseEntity<List<User>> getAll(@RequestParam Optional<Integer> page, ...)
+//
+// With page, page_size, sort or filter[...] params the response is one page:
+//
+//	GET /api/users?filter[age]=gte:18&sort=-age&page=1&page_size=20
+//
+// With ids it is a batch lookup (ids that don't exist are left out):
+//
+//	GET /api/users?ids=1,2,3
 func (h *UserHandler) GetAll(c *gin.Context) {
-	// Check for pagination parameters
-	pageStr := c.Query("page")
-	pageSizeStr := c.Query("page_size")
-
-	if pageStr != "" || pageSizeStr != "" {
-		// Paginated response
-		page, _ := strconv.Atoi(pageStr)
-		pageSize, _ := strconv.Atoi(pageSizeStr)
+	params := c.Request.URL.Query()
+	if raw, found := c.GetQuery("ids"); found {
+		// Batch lookup - saves clients one request per user
+		ids, err := parseIDs(raw)
+		if err != nil {
+			fail(c, http.StatusBadRequest, err)
+			return
+		}
 
-		if page < 1 {
-			page = 1
+		users, err := h.service.GetByIDs(ids)
+		if err != nil {
+			fail(c, http.StatusInternalServerError, err)
+			return
 		}
-		if pageSize < 1 {
-			pageSize = 10
+
+		ok(c, users)
+		return
+	}
+
+	if querybuilder.IsListQuery(params) {
+		// Filtered / sorted / paginated response
+		q, err := querybuilder.Parse(params, repository.UserQueryFields)
+		if err != nil {
+			fail(c, http.StatusBadRequest, err)
+			return
 		}
 
-		users, total, err := h.service.GetAllPaginated(page, pageSize)
+		users, total, err := h.service.ListUsers(c.Request.Context(), q) // Sets q.PageSize to the size used
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			fail(c, http.StatusInternalServerError, err)
 			return
 		}
 
-		totalPages := int(total) / pageSize
-		if int(total)%pageSize > 0 {
+		totalPages := int(total) / q.PageSize
+		if int(total)%q.PageSize > 0 {
 			totalPages++
 		}
 
-		c.JSON(http.StatusOK, PaginatedResponse{
-			Data:       users,
-			Page:       page,
-			PageSize:   pageSize,
+		okPage(c, users, response.PageMeta{
+			Page:       q.Page,
+			PageSize:   q.PageSize,
 			TotalCount: total,
 			TotalPages: totalPages,
 		})
@@ -157,13 +212,13 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 	}
 
 	// Non-paginated response
-	users, err := h.service.GetAll()
+	users, err := h.service.GetAll(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	ok(c, users)
 }
 
 // GetByID handles GET /api/users/:id
@@ -174,7 +229,7 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user id"})
+		fail(c, http.StatusBadRequest, errInvalidUserID)
 		return
 	}
 
@@ -190,11 +245,11 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 	}
 
 	if err != nil {
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusNotFound, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	ok(c, user)
 }
 
 // Update handles PUT /api/users/:id
@@ -203,21 +258,21 @@ func (h *UserHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user id"})
+		fail(c, http.StatusBadRequest, errInvalidUserID)
 		return
 	}
 
 	// Get existing user
 	user, err := h.service.GetByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusNotFound, err)
 		return
 	}
 
 	// Bind update data
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -226,7 +281,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 	user.Email = req.Email
 	user.Age = req.Age
 
-	c.JSON(http.StatusOK, user)
+	ok(c, user)
 }
 
 // Delete handles DELETE /api/users/:id
@@ -235,12 +290,12 @@ func (h *UserHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user id"})
+		fail(c, http.StatusBadRequest, errInvalidUserID)
 		return
 	}
 
 	if err := h.service.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusNotFound, err)
 		return
 	}
 
@@ -253,13 +308,13 @@ func (h *UserHandler) Delete(c *gin.Context) {
 func (h *UserHandler) Search(c *gin.Context) {
 	query := c.Query("q")
 
-	users, err := h.service.SearchUsers(query)
+	users, err := h.service.SearchUsers(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	ok(c, users)
 }
 
 // UpdateProfile handles PUT /api/users/:id/profile
@@ -267,21 +322,39 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid user id"})
+		fail(c, http.StatusBadRequest, errInvalidUserID)
 		return
 	}
 
 	var req UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := h.service.UpdateProfile(uint(id), req.Bio, req.AvatarURL, req.Website); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		fail(c, http.StatusBadRequest, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "profile updated"})
+	ok(c, req) // The profile fields as saved
 }
 
+// Stats handles GET /api/users/:id/stats
+// Java: @GetMapping("/{id}/stats") public PostStats stats(@PathVariable Long id)
+func (h *UserHandler) Stats(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		fail(c, http.StatusBadRequest, errInvalidUserID)
+		return
+	}
+
+	stats, err := h.service.GetPostStats(uint(id))
+	if err != nil {
+		fail(c, http.StatusNotFound, err)
+		return
+	}
+
+	ok(c, stats)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+)
+
+// =============================================================================
+// USER STREAM HANDLER - Server-Sent Events
+// =============================================================================
+// In Java/Spring: @GetMapping(produces = TEXT_EVENT_STREAM_VALUE) returning SseEmitter
+// Go/Gin: Keep the response open and write "event:/data:" frames as users arrive
+
+// UserStreamHandler pushes newly registered users to connected clients
+type UserStreamHandler struct {
+	events *service.UserBroadcaster
+}
+
+// NewUserStreamHandler creates a UserStreamHandler reading from events
+func NewUserStreamHandler(events *service.UserBroadcaster) *UserStreamHandler {
+	return &UserStreamHandler{events: events}
+}
+
+// RegisterRoutes sets up the stream endpoint
+func (h *UserStreamHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/api/users/stream", h.Stream) // GET /api/users/stream
+}
+
+// Stream handles GET /api/users/stream
+// Each registration is sent as:
+//
+//	event: user_created
+//	data: {"id":1,"name":"...","email":"...","age":0}
+func (h *UserStreamHandler) Stream(c *gin.Context) {
+	users, unsubscribe := h.events.Subscribe()
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	// Send headers now so the client knows it's subscribed before any event
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// The request context is cancelled when the client disconnects
+	ctx := c.Request.Context()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case user := <-users:
+			c.SSEvent("user_created", UserResponse{
+				ID:    user.ID,
+				Name:  user.Name,
+				Email: user.Email,
+				Age:   user.Age,
+			})
+			return true
+		}
+	})
+}
@@ -1,16 +1,32 @@
 package main
 
 import (
+	"compress/gzip"
+	"context"
+	_ "embed"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/config"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/pretty"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/seed"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"github.com/viraj/go-mono-repo/projects/hello-world/lifecycle"
+	"go.uber.org/zap"
 )
 
 // =============================================================================
@@ -21,7 +37,7 @@ import (
 
 func main() {
 	fmt.Println("🚀 Starting Database Integration Exercise...")
-	fmt.Println("=" + repeatChar('=', 59))
+	fmt.Println(pretty.Separator('=', 60))
 
 	// ==========================================================================
 	// STEP 1: Connect to Database
@@ -32,6 +48,15 @@ func main() {
 		log.Fatal("❌ Failed to connect to database:", err)
 	}
 
+	// Route SQL logs through zap instead of stdout; queries over 200ms are flagged
+	// Java equivalent: hibernate.show_sql + slow query logging
+	zapLog, err := zap.NewDevelopment()
+	if err != nil {
+		log.Fatal("❌ Failed to create logger:", err)
+	}
+	defer zapLog.Sync()
+	database.UseZapLogger(zapLog, 200*time.Millisecond)
+
 	// ==========================================================================
 	// STEP 2: Run Migrations (Create Tables)
 	// ==========================================================================
@@ -50,25 +75,45 @@ func main() {
 	// ==========================================================================
 	// STEP 4: Demonstrate Various Operations
 	// ==========================================================================
-	log.Println("\n" + repeatChar('=', 60))
+	log.Println("\n" + pretty.Separator('=', 60))
 	log.Println("📚 DEMONSTRATING DATABASE OPERATIONS")
-	log.Println(repeatChar('=', 60))
+	log.Println(pretty.Separator('=', 60))
 
 	demonstrateOperations()
 
 	// ==========================================================================
 	// STEP 5: Start HTTP Server
 	// ==========================================================================
-	log.Println("\n" + repeatChar('=', 60))
+	log.Println("\n" + pretty.Separator('=', 60))
 	log.Println("🌐 STARTING HTTP SERVER")
-	log.Println(repeatChar('=', 60))
+	log.Println(pretty.Separator('=', 60))
 
-	startServer()
+	startServer(zapLog)
 }
 
 // =============================================================================
 // SEED DATA - Create initial test data
 // =============================================================================
+
+// defaultConfig is config.yaml, compiled in so the binary runs from any directory
+// Set CONFIG_FILE to use a deployment's own file instead
+//
+//go:embed config.yaml
+var defaultConfig []byte
+
+// loadConfig reads $CONFIG_FILE when set (it must exist), else the embedded config.yaml
+func loadConfig() (*config.Config, error) {
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		return config.Parse(defaultConfig, "config.yaml")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	return config.Parse(data, path)
+}
+
 func seedData() {
 	db := database.GetDB()
 
@@ -80,168 +125,15 @@ func seedData() {
 		return
 	}
 
-	// --------------------------------------------------------------------------
-	// Create Users with Profiles (One-to-One)
-	// --------------------------------------------------------------------------
-	users := []model.User{
-		{
-			Name:  "Viraj Pansuriya",
-			Email: "viraj@example.com",
-			Age:   25,
-			Profile: model.Profile{
-				Bio:       "Go learner from Java/C++ background",
-				AvatarURL: "https://example.com/viraj.jpg",
-				Website:   "https://viraj.dev",
-			},
-		},
-		{
-			Name:  "Alice Developer",
-			Email: "alice@example.com",
-			Age:   28,
-			Profile: model.Profile{
-				Bio:     "Full-stack developer",
-				Website: "https://alice.dev",
-			},
-		},
-		{
-			Name:  "Bob Engineer",
-			Email: "bob@example.com",
-			Age:   32,
-			Profile: model.Profile{
-				Bio: "Backend specialist",
-			},
-		},
-	}
-
-	for _, user := range users {
-		if err := db.Create(&user).Error; err != nil {
-			log.Printf("⚠️ Failed to create user %s: %v", user.Name, err)
-		}
-	}
-	log.Println("✅ Created users with profiles")
-
-	// --------------------------------------------------------------------------
-	// Create Posts for Users (One-to-Many)
-	// --------------------------------------------------------------------------
-	posts := []model.Post{
-		{Title: "My Go Journey", Content: "Learning Go from Java...", UserID: 1},
-		{Title: "GORM vs Hibernate", Content: "Comparing ORMs...", UserID: 1},
-		{Title: "Concurrency in Go", Content: "Goroutines are amazing!", UserID: 2},
-		{Title: "REST APIs with Gin", Content: "Building APIs...", UserID: 2},
-	}
-
-	for _, post := range posts {
-		db.Create(&post)
-	}
-	log.Println("✅ Created posts")
-
-	// --------------------------------------------------------------------------
-	// Create Authors and Books with Tags (One-to-Many + Many-to-Many)
-	// --------------------------------------------------------------------------
-	// First, create tags
-	tags := []model.Tag{
-		{Name: "Programming", Color: "#3498db"},
-		{Name: "Fiction", Color: "#e74c3c"},
-		{Name: "Science", Color: "#2ecc71"},
-		{Name: "History", Color: "#f39c12"},
-		{Name: "Go", Color: "#00ADD8"},
-	}
-	for _, tag := range tags {
-		db.Create(&tag)
-	}
-	log.Println("✅ Created tags")
-
-	// Create authors with books
-	birthDate := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
-	authors := []model.Author{
-		{
-			Name:      "Robert C. Martin",
-			Country:   "USA",
-			BirthDate: &birthDate,
-			Books: []model.Book{
-				{Title: "Clean Code", ISBN: "978-0132350884", Price: 39.99},
-				{Title: "Clean Architecture", ISBN: "978-0134494166", Price: 34.99},
-			},
-		},
-		{
-			Name:    "Donovan & Kernighan",
-			Country: "USA",
-			Books: []model.Book{
-				{Title: "The Go Programming Language", ISBN: "978-0134190440", Price: 44.99},
-			},
-		},
-	}
-
-	for _, author := range authors {
-		db.Create(&author)
-	}
-	log.Println("✅ Created authors with books")
-
-	// Associate tags with books (Many-to-Many)
-	var cleanCode model.Book
-	db.Where("title = ?", "Clean Code").First(&cleanCode)
-	var programmingTag, goTag model.Tag
-	db.Where("name = ?", "Programming").First(&programmingTag)
-	db.Where("name = ?", "Go").First(&goTag)
-	db.Model(&cleanCode).Association("Tags").Append(&programmingTag)
-
-	var goBook model.Book
-	db.Where("title = ?", "The Go Programming Language").First(&goBook)
-	db.Model(&goBook).Association("Tags").Append(&programmingTag, &goTag)
-	log.Println("✅ Associated tags with books")
-
-	// --------------------------------------------------------------------------
-	// Create Students, Courses, and Enrollments (Many-to-Many with attributes)
-	// --------------------------------------------------------------------------
-	students := []model.Student{
-		{Name: "John Doe", StudentCode: "STU001", Email: "john@university.edu"},
-		{Name: "Jane Smith", StudentCode: "STU002", Email: "jane@university.edu"},
-		{Name: "Mike Johnson", StudentCode: "STU003", Email: "mike@university.edu"},
-	}
-	for _, student := range students {
-		db.Create(&student)
-	}
-	log.Println("✅ Created students")
-
-	courses := []model.Course{
-		{Name: "Go Programming", Code: "CS101", Credits: 3, MaxStudents: 30, Price: 299.99},
-		{Name: "Database Systems", Code: "CS201", Credits: 4, MaxStudents: 25, Price: 349.99},
-		{Name: "Web Development", Code: "CS301", Credits: 3, MaxStudents: 35, Price: 279.99},
-	}
-	for _, course := range courses {
-		db.Create(&course)
+	// The data itself lives in a fixture file (seed/data.yaml, embedded), not in code
+	// Java equivalent: spring.sql.init.data-locations=classpath:data.sql
+	fixture, err := seed.Sample()
+	if err != nil {
+		log.Fatal("❌ Failed to read seed data:", err)
 	}
-	log.Println("✅ Created courses")
-
-	// Create enrollments with grades
-	gradeA := "A"
-	gradeB := "B"
-	enrollments := []model.Enrollment{
-		{StudentID: 1, CourseID: 1, EnrolledAt: time.Now().AddDate(0, -2, 0), Grade: &gradeA, Completed: true},
-		{StudentID: 1, CourseID: 2, EnrolledAt: time.Now().AddDate(0, -1, 0), Grade: &gradeB, Completed: false},
-		{StudentID: 2, CourseID: 1, EnrolledAt: time.Now().AddDate(0, -2, 0), Grade: &gradeA, Completed: true},
-		{StudentID: 2, CourseID: 3, EnrolledAt: time.Now(), Completed: false},
-		{StudentID: 3, CourseID: 2, EnrolledAt: time.Now().AddDate(0, -1, 0), Completed: false},
+	if err := seed.Load(db, fixture); err != nil {
+		log.Fatal("❌ Failed to seed data:", err)
 	}
-	for _, enrollment := range enrollments {
-		db.Create(&enrollment)
-	}
-	log.Println("✅ Created enrollments")
-
-	// --------------------------------------------------------------------------
-	// Create Category Tree (Self-referential)
-	// --------------------------------------------------------------------------
-	techCategory := model.Category{Name: "Technology", Description: "Tech topics"}
-	db.Create(&techCategory)
-
-	subCategories := []model.Category{
-		{Name: "Programming", Description: "Programming languages", ParentID: &techCategory.ID},
-		{Name: "Databases", Description: "Database systems", ParentID: &techCategory.ID},
-	}
-	for _, cat := range subCategories {
-		db.Create(&cat)
-	}
-	log.Println("✅ Created category tree")
 
 	fmt.Println("\n✅ Sample data seeded successfully!")
 }
@@ -255,8 +147,7 @@ func demonstrateOperations() {
 	// --------------------------------------------------------------------------
 	// 1. One-to-One: User with Profile
 	// --------------------------------------------------------------------------
-	fmt.Println("\n📌 ONE-TO-ONE: User with Profile")
-	fmt.Println(repeatChar('-', 40))
+	fmt.Println(pretty.Section("ONE-TO-ONE: User with Profile"))
 
 	var userWithProfile model.User
 	db.Preload("Profile").First(&userWithProfile, 1)
@@ -267,8 +158,7 @@ func demonstrateOperations() {
 	// --------------------------------------------------------------------------
 	// 2. One-to-Many: User with Posts
 	// --------------------------------------------------------------------------
-	fmt.Println("\n📌 ONE-TO-MANY: User with Posts")
-	fmt.Println(repeatChar('-', 40))
+	fmt.Println(pretty.Section("ONE-TO-MANY: User with Posts"))
 
 	var userWithPosts model.User
 	db.Preload("Posts").First(&userWithPosts, 1)
@@ -280,8 +170,7 @@ func demonstrateOperations() {
 	// --------------------------------------------------------------------------
 	// 3. One-to-Many: Author with Books
 	// --------------------------------------------------------------------------
-	fmt.Println("\n📌 ONE-TO-MANY: Author with Books")
-	fmt.Println(repeatChar('-', 40))
+	fmt.Println(pretty.Section("ONE-TO-MANY: Author with Books"))
 
 	var author model.Author
 	db.Preload("Books").First(&author, 1)
@@ -293,8 +182,7 @@ func demonstrateOperations() {
 	// --------------------------------------------------------------------------
 	// 4. Many-to-Many: Book with Tags
 	// --------------------------------------------------------------------------
-	fmt.Println("\n📌 MANY-TO-MANY: Book with Tags")
-	fmt.Println(repeatChar('-', 40))
+	fmt.Println(pretty.Section("MANY-TO-MANY: Book with Tags"))
 
 	var bookWithTags model.Book
 	db.Preload("Tags").Where("title = ?", "The Go Programming Language").First(&bookWithTags)
@@ -311,8 +199,7 @@ func demonstrateOperations() {
 	// --------------------------------------------------------------------------
 	// 5. Many-to-Many with Join Attributes: Student Enrollments
 	// --------------------------------------------------------------------------
-	fmt.Println("\n📌 MANY-TO-MANY WITH ATTRIBUTES: Student Enrollments")
-	fmt.Println(repeatChar('-', 40))
+	fmt.Println(pretty.Section("MANY-TO-MANY WITH ATTRIBUTES: Student Enrollments"))
 
 	var studentEnrollments []model.Enrollment
 	db.Preload("Student").Preload("Course").Where("student_id = ?", 1).Find(&studentEnrollments)
@@ -334,8 +221,7 @@ func demonstrateOperations() {
 	// --------------------------------------------------------------------------
 	// 6. Self-referential: Category Tree
 	// --------------------------------------------------------------------------
-	fmt.Println("\n📌 SELF-REFERENTIAL: Category Tree")
-	fmt.Println(repeatChar('-', 40))
+	fmt.Println(pretty.Section("SELF-REFERENTIAL: Category Tree"))
 
 	var parentCategory model.Category
 	db.Preload("Children").First(&parentCategory, 1)
@@ -348,8 +234,7 @@ func demonstrateOperations() {
 	// --------------------------------------------------------------------------
 	// 7. Complex Query: Books by Tag
 	// --------------------------------------------------------------------------
-	fmt.Println("\n📌 COMPLEX QUERY: Find books by tag name")
-	fmt.Println(repeatChar('-', 40))
+	fmt.Println(pretty.Section("COMPLEX QUERY: Find books by tag name"))
 
 	bookRepo := repository.NewBookRepository(db)
 	books, _ := bookRepo.FindByTagName("Programming")
@@ -361,8 +246,7 @@ func demonstrateOperations() {
 	// --------------------------------------------------------------------------
 	// 8. Aggregation: Course Statistics
 	// --------------------------------------------------------------------------
-	fmt.Println("\n📌 AGGREGATION: Course Statistics")
-	fmt.Println(repeatChar('-', 40))
+	fmt.Println(pretty.Section("AGGREGATION: Course Statistics"))
 
 	enrollmentRepo := repository.NewEnrollmentRepository(db)
 	count, _ := enrollmentRepo.CountStudentsInCourse(1)
@@ -375,7 +259,7 @@ func demonstrateOperations() {
 // =============================================================================
 // START HTTP SERVER
 // =============================================================================
-func startServer() {
+func startServer(zapLog *zap.Logger) {
 	// Get database instance
 	db := database.GetDB()
 
@@ -387,30 +271,89 @@ func startServer() {
 
 	// Create repositories
 	userRepo := repository.NewUserRepository(db)
+	bookRepo := repository.NewBookRepository(db)
+
+	// In-process event bus for new registrations (feeds the SSE stream)
+	userEvents := service.NewUserBroadcaster()
 
 	// Create services with injected repositories
-	userService := service.NewUserService(userRepo)
+	// Short-lived cache for list/search pages, dropped on every user write
+	userCache := middleware.NewResponseCache(30 * time.Second)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatal("❌ Failed to load config:", err)
+	}
+
+	var userService service.UserService = service.NewUserService(userRepo,
+		service.WithPagination(cfg.Pagination),
+		service.WithEvents(userEvents),
+		service.WithCacheInvalidation(func() { userCache.Invalidate("/api/users") }),
+	)
+	// Warn about service calls over 100ms (SQL over 200ms is already logged by GORM)
+	userService = service.NewLoggingUserService(userService, zapLog, 100*time.Millisecond)
+	bookService := service.NewBookService(bookRepo)
 
 	// Create handlers with injected services
-	userHandler := handler.NewUserHandler(userService)
+	userHandler := handler.NewUserHandler(userService).UseCache(userCache)
+	userStreamHandler := handler.NewUserStreamHandler(userEvents)
+	adminHandler := handler.NewAdminHandler(userService, repository.NewStatsRepository(db))
+	bookHandler := handler.NewBookHandler(bookService)
+
+	// Admin API token from the environment; without it admin routes always return 401
+	adminTokens := map[string]middleware.Principal{}
+	if token := os.Getenv("ADMIN_TOKEN"); token != "" {
+		adminTokens[token] = middleware.Principal{Role: "admin"}
+	}
 
 	// --------------------------------------------------------------------------
 	// Setup Gin Router
 	// --------------------------------------------------------------------------
 	r := gin.Default()
-
-	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "healthy"})
+	r.Use(middleware.RequestID())                   // X-Request-ID, also tags queries run with the request context
+	r.Use(middleware.ClientDisconnect(zapLog))      // Log (and 499) requests the client abandoned
+	r.Use(middleware.APIVersion("1"))               // Accept-Version; only v1 so far
+	r.Use(middleware.Gzip(gzip.DefaultCompression)) // Large user lists compress well
+	r.Use(middleware.RequireJSON())                 // 415 for writes without a JSON body
+
+	// Health check endpoint: every subsystem registers a named check
+	healthChecker := handler.NewHealthChecker(2 * time.Second)
+	healthChecker.Register("database", database.Ping)
+	healthChecker.Register("migrations", func(ctx context.Context) error {
+		version, err := database.CurrentVersion()
+		if err != nil {
+			return err
+		}
+		if version != database.SchemaVersion {
+			return fmt.Errorf("schema version %q, expected %q", version, database.SchemaVersion)
+		}
+		return nil
 	})
 
+	// Prometheus metrics: connection pool gauges sampled every 5s
+	// Java: micrometer-registry-prometheus + /actuator/prometheus
+	poolMetrics, err := database.NewPoolMetrics(db, prometheus.DefaultRegisterer, 5*time.Second)
+	if err != nil {
+		log.Fatal("Failed to register pool metrics:", err)
+	}
+	poolMetrics.Start()
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	// Register routes
+	healthChecker.RegisterRoutes(r)
 	userHandler.RegisterRoutes(r)
+	userStreamHandler.RegisterRoutes(r)
+	bookHandler.RegisterRoutes(r)
+	adminHandler.RegisterRoutes(r, middleware.TokenAuth(adminTokens))
 
 	// --------------------------------------------------------------------------
 	// Print API documentation
 	// --------------------------------------------------------------------------
 	fmt.Println("\n📍 API Endpoints:")
+	fmt.Println("  GET    /health              - Aggregated health checks (503 if any fail)")
+	fmt.Println("  GET    /livez               - Liveness probe (always 200 while running)")
+	fmt.Println("  GET    /readyz              - Readiness probe (DB reachable, migrations done)")
+	fmt.Println("  GET    /metrics             - Prometheus metrics (DB pool gauges)")
 	fmt.Println("  POST   /api/users           - Register new user")
 	fmt.Println("  GET    /api/users           - Get all users (supports pagination)")
 	fmt.Println("  GET    /api/users/:id       - Get user by ID")
@@ -418,6 +361,12 @@ func startServer() {
 	fmt.Println("  DELETE /api/users/:id       - Delete user (soft delete)")
 	fmt.Println("  GET    /api/users/search?q= - Search users")
 	fmt.Println("  PUT    /api/users/:id/profile - Update profile")
+	fmt.Println("  GET    /api/users/:id/stats - Post count and last post date")
+	fmt.Println("  GET    /api/users/stream    - Live feed of new users (SSE)")
+	fmt.Println("  DELETE /api/admin/users/:id?purge=true - Hard delete (admin)")
+	fmt.Println("  POST   /api/admin/users/purge - Purge users soft-deleted > N days ago (admin)")
+	fmt.Println("  POST   /api/admin/users/bulk-delete - Soft delete users by id (admin)")
+	fmt.Println("  GET    /api/admin/stats - Live/soft-deleted row counts (admin)")
 
 	fmt.Println("\n🚀 Server starting on http://localhost:8080")
 	fmt.Println("📝 Try: curl http://localhost:8080/api/users")
@@ -425,17 +374,38 @@ func startServer() {
 	// --------------------------------------------------------------------------
 	// Start Server
 	// --------------------------------------------------------------------------
-	if err := r.Run(":8080"); err != nil {
-		log.Fatal("Failed to start server:", err)
-	}
-}
-
-// Helper function to repeat a character
-func repeatChar(char rune, count int) string {
-	result := make([]rune, count)
-	for i := range result {
-		result[i] = char
+	srv := &http.Server{Addr: ":8080", Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	// Graceful shutdown on Ctrl+C / SIGTERM
+	// Like Spring's server.shutdown=graceful
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	fmt.Println("\n🛑 Shutting down...")
+
+	// Stop taking requests, let in-flight work finish, flush logs, then close the DB
+	// 10s in total; the server gets 5s of it, since an open /api/users/stream client
+	// keeps Shutdown waiting until then. Logs and the DB are flushed/closed regardless
+	shutdown := lifecycle.New()
+	shutdown.Register("http server", lifecycle.PriorityServer, srv, lifecycle.Budget(5*time.Second))
+	shutdown.RegisterFunc("pool metrics", lifecycle.PriorityWorkers, func(ctx context.Context) error {
+		poolMetrics.Stop()
+		return nil
+	})
+	shutdown.RegisterFunc("logs", lifecycle.PriorityLogs, func(ctx context.Context) error {
+		_ = zapLog.Sync() // "sync /dev/stderr: invalid argument" on terminals is harmless
+		return nil
+	}, lifecycle.Always())
+	shutdown.RegisterFunc("database", lifecycle.PriorityDatabase, func(ctx context.Context) error {
+		return database.Close()
+	}, lifecycle.Always())
+	if err := shutdown.Shutdown(10 * time.Second); err != nil {
+		log.Println("Shutdown incomplete:", err)
 	}
-	return string(result)
 }
-
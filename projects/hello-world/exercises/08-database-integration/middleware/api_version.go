@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/response"
+)
+
+// =============================================================================
+// API VERSION NEGOTIATION
+// =============================================================================
+// In Java/Spring: a HandlerInterceptor reading a version header
+// (Spring 7's ApiVersionConfigurer.useRequestHeader("Accept-Version"))
+// Go/Gin: A middleware that validates the header and stores the version for handlers
+//
+// Clients pin a version with "Accept-Version: 1"; without the header they get
+// the latest. The negotiated version is echoed back in the Content-Version header.
+
+const (
+	AcceptVersionHeader  = "Accept-Version"
+	ContentVersionHeader = "Content-Version"
+	apiVersionKey        = "api_version"
+)
+
+// APIVersion accepts only the supported versions, listed oldest first
+// The last one is the latest and the default when the header is absent
+// Usage: r.Use(middleware.APIVersion("1", "2"))
+func APIVersion(supported ...string) gin.HandlerFunc {
+	if len(supported) == 0 {
+		panic("middleware: APIVersion needs at least one supported version")
+	}
+	latest := supported[len(supported)-1]
+	known := make(map[string]bool, len(supported))
+	for _, v := range supported {
+		known[v] = true
+	}
+
+	return func(c *gin.Context) {
+		version := c.GetHeader(AcceptVersionHeader)
+		if version == "" {
+			version = latest
+		}
+		if !known[version] {
+			response.AbortWithDetails(c, http.StatusBadRequest, "unsupported API version "+version,
+				gin.H{"supported": supported})
+			return
+		}
+		c.Set(apiVersionKey, version)
+		c.Header(ContentVersionHeader, version)
+		c.Next()
+	}
+}
+
+// GetAPIVersion returns the version negotiated by APIVersion, or "" when it isn't installed
+// Handlers branch on it: if middleware.GetAPIVersion(c) == "1" { ...old shape... }
+func GetAPIVersion(c *gin.Context) string {
+	return c.GetString(apiVersionKey)
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/response"
+)
+
+// =============================================================================
+// TOKEN AUTHENTICATION + ROLES
+// =============================================================================
+// In Java/Spring: a Spring Security filter resolving an Authentication,
+// plus @PreAuthorize("hasRole('ADMIN')") on protected endpoints
+// Go/Gin: One middleware resolves the caller, another checks the role
+//
+// Tokens are pre-shared (like API keys); a real app would verify a JWT here.
+
+const principalKey = "principal"
+
+// Principal is the authenticated caller
+type Principal struct {
+	UserID uint
+	Role   string
+}
+
+// TokenAuth resolves "Authorization: Bearer <token>" to a Principal, 401 otherwise
+func TokenAuth(tokens map[string]Principal) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		principal, known := tokens[token]
+		if !ok || token == "" || !known {
+			response.Abort(c, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// RequireRole allows only principals with role; must run after TokenAuth
+// 401 = we don't know who you are, 403 = we do, and you're not allowed
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			response.Abort(c, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		if principal.Role != role {
+			response.Abort(c, http.StatusForbidden, "forbidden: requires role "+role)
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetPrincipal returns the caller set by TokenAuth
+func GetPrincipal(c *gin.Context) (Principal, bool) {
+	principal, ok := c.Get(principalKey)
+	if !ok {
+		return Principal{}, false
+	}
+	p, ok := principal.(Principal)
+	return p, ok
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// =============================================================================
+// RESPONSE CACHE
+// =============================================================================
+// In Java/Spring: @Cacheable on the controller + @CacheEvict on writes
+// Go/Gin: A middleware caching serialized GET responses by URL
+//
+// The key is the path plus the sorted query string, so ?page=2&size=10 and
+// ?size=10&page=2 share an entry but different pages/filters don't.
+// Only 200 responses are cached; writes call Invalidate to drop stale pages.
+// Invalidate also bumps a generation counter, so a GET that read before the
+// write but finishes after it doesn't put its stale page back.
+
+// ResponseCache stores serialized responses for a short TTL
+type ResponseCache struct {
+	ttl time.Duration
+
+	mu         sync.RWMutex
+	entries    map[string]cachedResponse
+	generation uint64 // Bumped by Invalidate
+}
+
+type cachedResponse struct {
+	contentType string
+	body        []byte
+	expires     time.Time
+}
+
+// NewResponseCache creates an empty cache whose entries live for ttl
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{ttl: ttl, entries: make(map[string]cachedResponse)}
+}
+
+// CacheKey builds the cache key for a request URL
+func CacheKey(r *http.Request) string {
+	// Query().Encode() sorts by key, making parameter order irrelevant
+	if q := r.URL.Query().Encode(); q != "" {
+		return r.URL.Path + "?" + q
+	}
+	return r.URL.Path
+}
+
+// Middleware serves cached GET responses and stores fresh 200s
+// Sets X-Cache: HIT or MISS so caching is visible when debugging
+func (rc *ResponseCache) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		key := CacheKey(c.Request)
+		if entry, ok := rc.get(key); ok {
+			c.Header("X-Cache", "HIT")
+			c.Data(http.StatusOK, entry.contentType, entry.body)
+			c.Abort()
+			return
+		}
+
+		c.Header("X-Cache", "MISS")
+		gen := rc.currentGeneration()
+		w := &captureWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.Status() == http.StatusOK && len(c.Errors) == 0 {
+			rc.set(key, gen, cachedResponse{
+				contentType: w.Header().Get("Content-Type"),
+				body:        w.body.Bytes(),
+				expires:     time.Now().Add(rc.ttl),
+			})
+		}
+	}
+}
+
+// Invalidate drops every entry whose key starts with prefix ("" clears everything)
+// Java: @CacheEvict(allEntries = true)
+func (rc *ResponseCache) Invalidate(prefix string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generation++
+	for key := range rc.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// Len returns the number of stored entries, including expired ones not yet evicted
+func (rc *ResponseCache) Len() int {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return len(rc.entries)
+}
+
+func (rc *ResponseCache) get(key string) (cachedResponse, bool) {
+	rc.mu.RLock()
+	entry, ok := rc.entries[key]
+	rc.mu.RUnlock()
+	if !ok {
+		return cachedResponse{}, false
+	}
+	if time.Now().After(entry.expires) {
+		rc.mu.Lock()
+		delete(rc.entries, key)
+		rc.mu.Unlock()
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (rc *ResponseCache) currentGeneration() uint64 {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.generation
+}
+
+// set stores entry unless Invalidate ran since gen was read
+func (rc *ResponseCache) set(key string, gen uint64, entry cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.generation != gen {
+		return // Response may predate a write
+	}
+	rc.entries[key] = entry
+}
+
+// captureWriter copies the body while still writing it to the client
+type captureWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
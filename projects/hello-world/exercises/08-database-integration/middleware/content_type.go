@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/response"
+)
+
+// =============================================================================
+// CONTENT-TYPE ENFORCEMENT
+// =============================================================================
+// In Java/Spring: @PostMapping(consumes = MediaType.APPLICATION_JSON_VALUE)
+// Go/Gin: A middleware that checks the header before any binding happens
+//
+// Without it, ShouldBindJSON on a form or plain-text body fails with a
+// confusing decoder error instead of telling the client what went wrong.
+
+// RequireJSON rejects POST/PUT/PATCH requests whose Content-Type isn't JSON with 415
+// Accepts parameters (charset) and structured suffixes like application/merge-patch+json
+func RequireJSON() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			c.Next()
+			return
+		}
+
+		if !isJSONContentType(c.GetHeader("Content-Type")) {
+			response.Abort(c, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		c.Next()
+	}
+}
+
+func isJSONContentType(header string) bool {
+	if header == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// =============================================================================
+// CLIENT DISCONNECT
+// =============================================================================
+// In Java/Spring: catching ClientAbortException (Tomcat) / AsyncRequestNotUsableException
+// Go/Gin: net/http cancels c.Request.Context() as soon as the client goes away,
+// so work started with that context (db.WithContext, GetTx, outbound calls) stops early.
+//
+// This middleware makes the cancellation visible: it logs it and records the
+// response as 499 (nginx's "client closed request") instead of a misleading 200/500.
+
+// StatusClientClosedRequest is nginx's non-standard status for requests the client abandoned
+const StatusClientClosedRequest = 499
+
+// ClientDisconnect logs requests whose client disconnected before the handler finished
+func ClientDisconnect(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		if !errors.Is(c.Request.Context().Err(), context.Canceled) {
+			return
+		}
+		if !c.Writer.Written() {
+			c.Status(StatusClientClosedRequest) // Nobody reads it, but logs and metrics do
+		}
+		log.Info("client disconnected, request cancelled",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("request_id", GetRequestID(c)),
+			zap.Duration("elapsed", time.Since(start)),
+		)
+	}
+}
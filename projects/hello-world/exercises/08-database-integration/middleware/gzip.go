@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// =============================================================================
+// GZIP RESPONSE COMPRESSION
+// =============================================================================
+// In Java/Spring: server.compression.enabled=true (+ min-response-size)
+// Go/Gin: A middleware that buffers the response and compresses it on the way out
+//
+// The body is buffered so we can look at its size and Content-Type before
+// deciding. Streaming handlers (SSE) call Flush, which switches the writer
+// to pass-through so events aren't held back.
+
+// GzipMinSize is the smallest body worth compressing; below it gzip overhead wins
+const GzipMinSize = 1024
+
+// Gzip compresses responses for clients sending Accept-Encoding: gzip
+// level is a compress/gzip level (gzip.BestSpeed ... gzip.BestCompression, or gzip.DefaultCompression)
+func Gzip(level int) gin.HandlerFunc {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		panic(fmt.Sprintf("gzip: invalid compression level %d", level))
+	}
+	pool := sync.Pool{New: func() any {
+		gz, _ := gzip.NewWriterLevel(nil, level) // level validated above
+		return gz
+	}}
+
+	return func(c *gin.Context) {
+		if !acceptsGzip(c.Request) {
+			c.Next()
+			return
+		}
+
+		w := &gzipWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		defer func() { c.Writer = w.ResponseWriter }()
+
+		c.Next()
+
+		if w.passthrough {
+			return // Already flushed to the client uncompressed
+		}
+		if !shouldCompress(w) {
+			w.ResponseWriter.Write(w.buf.Bytes())
+			return
+		}
+
+		h := w.Header()
+		h.Set("Content-Encoding", "gzip")
+		h.Add("Vary", "Accept-Encoding")
+		h.Del("Content-Length")
+
+		gz := pool.Get().(*gzip.Writer)
+		defer pool.Put(gz)
+		gz.Reset(w.ResponseWriter)
+		gz.Write(w.buf.Bytes())
+		gz.Close()
+	}
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		// Ignore q-values; "gzip;q=0" is rare enough to not matter here
+		if strings.TrimSpace(strings.SplitN(enc, ";", 2)[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+func shouldCompress(w *gzipWriter) bool {
+	if w.buf.Len() < GzipMinSize {
+		return false
+	}
+	if w.Header().Get("Content-Encoding") != "" {
+		return false // Handler already encoded the body
+	}
+	switch w.Status() {
+	case http.StatusNoContent, http.StatusNotModified:
+		return false
+	}
+	return !isCompressedType(w.Header().Get("Content-Type"))
+}
+
+// isCompressedType reports content types that gzip can't shrink further
+func isCompressedType(contentType string) bool {
+	ct := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	switch {
+	case ct == "image/svg+xml":
+		return false // SVG is text
+	case strings.HasPrefix(ct, "image/"), strings.HasPrefix(ct, "video/"), strings.HasPrefix(ct, "audio/"):
+		return true
+	}
+	switch ct {
+	case "application/gzip", "application/x-gzip", "application/zip", "application/zstd",
+		"application/x-7z-compressed", "application/x-rar-compressed", "application/pdf":
+		return true
+	}
+	return false
+}
+
+// gzipWriter buffers the body until the handler chain finishes
+// gin's ResponseWriter sends headers lazily, so WriteHeader only records the status
+type gzipWriter struct {
+	gin.ResponseWriter
+	buf         bytes.Buffer
+	passthrough bool
+}
+
+func (w *gzipWriter) Write(b []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.buf.WriteString(s)
+}
+
+// WriteHeaderNow is deferred until we know whether Content-Encoding is needed
+func (w *gzipWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *gzipWriter) Written() bool {
+	return w.ResponseWriter.Written() || w.buf.Len() > 0
+}
+
+func (w *gzipWriter) Size() int {
+	if w.passthrough {
+		return w.ResponseWriter.Size()
+	}
+	return w.buf.Len()
+}
+
+// Flush gives up on compression: streaming responses go out as-is
+func (w *gzipWriter) Flush() {
+	if !w.passthrough {
+		w.passthrough = true
+		w.ResponseWriter.WriteHeaderNow()
+		w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+	w.ResponseWriter.Flush()
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+)
+
+// =============================================================================
+// REQUEST ID
+// =============================================================================
+// In Java/Spring: a OncePerRequestFilter that puts a traceId into the MDC
+// Go/Gin: A middleware that stores the id in the gin context and echoes it back
+//
+// The client's X-Request-ID is reused when present so ids match across services.
+// The id also goes on the request context, so queries run with
+// db.WithContext(c.Request.Context()) are logged with it by the zap GORM logger.
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+)
+
+// RequestID assigns every request an id, available via GetRequestID
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(database.ContextWithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// GetRequestID returns the id set by RequestID, or "" when the middleware isn't installed
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// newRequestID returns 16 random bytes, hex encoded
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // never fails since Go 1.24
+	return hex.EncodeToString(b)
+}
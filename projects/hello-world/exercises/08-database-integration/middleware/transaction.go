@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/response"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// REQUEST-SCOPED TRANSACTION
+// =============================================================================
+// In Java/Spring: @Transactional on the controller method
+// Go/Gin: A middleware that opens the transaction around the handler
+//
+// Commit happens only for 2xx responses; anything else (or a panic) rolls back.
+// The response is held back until the commit succeeds, so a failed commit
+// turns into a 500 instead of a 2xx for a write that never happened.
+
+const txKey = "db_tx"
+
+// Transaction opens a transaction per request and stores it in the gin context
+func Transaction(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tx := db.WithContext(c.Request.Context()).Begin()
+		if tx.Error != nil {
+			response.Abort(c, http.StatusInternalServerError, "failed to start transaction")
+			return
+		}
+
+		w := &txWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+
+		// Roll back and re-panic so gin.Recovery() still produces the 500
+		defer func() {
+			if r := recover(); r != nil {
+				tx.Rollback()
+				c.Writer = w.ResponseWriter
+				panic(r)
+			}
+		}()
+
+		c.Set(txKey, tx)
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		status := w.Status()
+		if status < 200 || status >= 300 || len(c.Errors) > 0 {
+			tx.Rollback()
+			w.ResponseWriter.Write(w.buf.Bytes())
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
+			log.Printf("❌ Failed to commit transaction for %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			// Nothing was sent yet: replace the handler's 2xx with the error
+			w.Header().Del("Content-Length")
+			response.Fail(c, http.StatusInternalServerError, err)
+			return
+		}
+		w.ResponseWriter.Write(w.buf.Bytes())
+	}
+}
+
+// GetTx returns the request transaction, or the plain DB when the middleware isn't installed
+// Either way queries are bound to the request context, so they stop if the client disconnects
+// Usage inside a handler:
+//
+//	tx := middleware.GetTx(c)
+//	tx.Create(&order)
+//	tx.Create(&orderItems)
+func GetTx(c *gin.Context) *gorm.DB {
+	if tx, exists := c.Get(txKey); exists {
+		return tx.(*gorm.DB)
+	}
+	return database.GetDB().WithContext(c.Request.Context())
+}
+
+// txWriter buffers the response until the transaction's outcome is known
+// Like gzipWriter, it relies on gin sending headers lazily: WriteHeader only records the status
+type txWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *txWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+func (w *txWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+// WriteHeaderNow waits for the commit; the status can still change to 500
+func (w *txWriter) WriteHeaderNow() {}
+
+func (w *txWriter) Written() bool {
+	return w.ResponseWriter.Written() || w.buf.Len() > 0
+}
+
+func (w *txWriter) Size() int {
+	return w.buf.Len()
+}
+
+// Flush can't stream from inside a transaction: everything waits for the commit
+func (w *txWriter) Flush() {}
@@ -3,6 +3,7 @@ package model
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -24,17 +25,82 @@ import (
 // User represents a system user
 // Think of it like @Entity in JPA/Hibernate
 type User struct {
-	gorm.Model                  // Embeds ID, CreatedAt, UpdatedAt, DeletedAt
-	Name    string  `gorm:"size:100;not null" json:"name"`                  // VARCHAR(100) NOT NULL
-	Email   string  `gorm:"size:100;uniqueIndex;not null" json:"email"`     // UNIQUE INDEX
-	Age     int     `gorm:"default:0" json:"age"`                           // DEFAULT 0
-	Profile Profile `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"` // ONE-TO-ONE: User has one Profile
+	gorm.Model         // Embeds ID, CreatedAt, UpdatedAt, DeletedAt
+	Name       string  `gorm:"size:100;not null" json:"name"`                                                                             // VARCHAR(100) NOT NULL
+	Email      string  `gorm:"size:100;index:idx_users_email_lookup;uniqueIndex:idx_users_tenant_email,priority:2;not null" json:"email"` // UNIQUE (tenant_id, email), plus an email index
+	Age        int     `gorm:"default:0" json:"age"`                                                                                      // DEFAULT 0
+	PublicID   string  `gorm:"size:36;uniqueIndex;not null" json:"public_id"`                                                             // UUID for URLs; set by BeforeSave
+	Role       string  `gorm:"size:20;not null;default:user" json:"role"`                                                                 // One of Roles; DEFAULT 'user'
+	TenantID   uint    `gorm:"index;uniqueIndex:idx_users_tenant_email,priority:1;not null;default:0" json:"tenant_id"`                   // Owning tenant; 0 = single-tenant
+	Profile    Profile `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`                                                            // ONE-TO-ONE: User has one Profile
 
 	// ONE-TO-MANY: User has many Posts
 	// Java: @OneToMany(mappedBy = "user", cascade = CascadeType.ALL)
 	Posts []Post `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"posts,omitempty"`
 }
 
+// User roles; Roles is the allowlist role changes are validated against
+// Java: enum Role { USER, ADMIN } mapped with @Enumerated(EnumType.STRING)
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+var Roles = []string{RoleUser, RoleAdmin}
+
+// BeforeSave gives every user without one a random UUID PublicID
+// The integer ID stays the primary key; PublicID is what URLs should expose,
+// since sequential IDs leak how many users exist
+// Runs on Save too, so a row loaded without one can't write "" into the unique index
+// Java: @PrePersist @PreUpdate void assignPublicId() { if (publicId == null) publicId = UUID.randomUUID(); }
+func (u *User) BeforeSave(tx *gorm.DB) error {
+	if u.PublicID == "" {
+		u.PublicID = uuid.NewString()
+	}
+	return nil
+}
+
+// BeforeDelete soft-deletes the profile and posts of the users being soft-deleted
+// Runs for db.Delete(&user) as well as db.Delete(&User{}, ids) / db.Where(...).Delete(&User{}),
+// inside the same transaction, so repositories needn't remember to cascade
+// Hard deletes (Unscoped) are left alone: ON DELETE CASCADE / purgeUsers handle those
+// Java: @OneToMany(cascade = CascadeType.REMOVE) with @SQLDelete soft-delete on each entity
+func (u *User) BeforeDelete(tx *gorm.DB) error {
+	if tx.Statement.Unscoped {
+		return nil
+	}
+
+	// The WHERE from Delete's conditions is already on the statement; the primary key
+	// of u is only added later by the delete callback, so add it here
+	users := tx.Session(&gorm.Session{NewDB: true}).Model(&User{})
+	where, hasWhere := tx.Statement.Clauses["WHERE"]
+	switch {
+	case u.ID != 0:
+		users = users.Where("id = ?", u.ID)
+	case !hasWhere:
+		return nil // GORM refuses the unconditioned delete itself
+	}
+	if hasWhere {
+		users = users.Clauses(where.Expression)
+	}
+
+	var ids []uint
+	if err := users.Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	children := tx.Session(&gorm.Session{NewDB: true})
+	for _, dependent := range []interface{}{&Profile{}, &Post{}} {
+		if err := children.Where("user_id IN ?", ids).Delete(dependent).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Profile - ONE-TO-ONE with User (the "owned" side)
 // Java equivalent:
 // @Entity class Profile {
@@ -71,6 +137,29 @@ type Post struct {
 	Replies  []Post `gorm:"foreignKey:ParentID" json:"replies,omitempty"`
 }
 
+// PostStats is an aggregate over a user's posts (not a table)
+// Java: a DTO projection from @Query("SELECT new PostStats(COUNT(p), MAX(p.createdAt)) ...")
+type PostStats struct {
+	PostCount  int64      `json:"post_count"`
+	LastPostAt *time.Time `json:"last_post_at"` // null when the user has no posts
+}
+
+// TableCount is how many rows of a table are live and how many are soft-deleted (not a table)
+type TableCount struct {
+	Live    int64 `json:"live"`
+	Deleted int64 `json:"deleted"`
+}
+
+// DatabaseStats is the admin dashboard's row counts (not a table)
+// Java: a DTO filled from several repository.count() calls
+type DatabaseStats struct {
+	Users       TableCount `json:"users"`
+	Books       TableCount `json:"books"`
+	Courses     TableCount `json:"courses"`
+	Enrollments TableCount `json:"enrollments"`
+	Posts       TableCount `json:"posts"`
+}
+
 // =============================================================================
 // ONE-TO-MANY RELATIONSHIP: Author → Books (Another example)
 // =============================================================================
@@ -100,6 +189,13 @@ type Book struct {
 	Tags []Tag `gorm:"many2many:book_tags;" json:"tags,omitempty"`
 }
 
+// RelatedBook is a book plus how many tags it shares with another book (not a table)
+// Java: a DTO projection from a GROUP BY query
+type RelatedBook struct {
+	Book
+	SharedTags int64 `json:"shared_tags"`
+}
+
 // =============================================================================
 // MANY-TO-MANY RELATIONSHIP: Books ↔ Tags
 // =============================================================================
@@ -146,6 +242,11 @@ type Course struct {
 	MaxStudents int     `gorm:"default:30" json:"max_students"`
 	Price       float64 `gorm:"type:decimal(10,2)" json:"price"`
 
+	// Weekly schedule; all empty means unscheduled (never conflicts)
+	StartTime  string `gorm:"size:5" json:"start_time,omitempty"`    // "HH:MM", 24h
+	EndTime    string `gorm:"size:5" json:"end_time,omitempty"`      // "HH:MM", after StartTime
+	DaysOfWeek string `gorm:"size:27" json:"days_of_week,omitempty"` // Comma separated: "Mon,Wed,Fri"
+
 	// Many-to-Many through Enrollment
 	Enrollments []Enrollment `gorm:"foreignKey:CourseID" json:"enrollments,omitempty"`
 }
@@ -166,6 +267,38 @@ type Enrollment struct {
 	Course  Course  `gorm:"foreignKey:CourseID" json:"course,omitempty"`
 }
 
+// Waitlist holds students waiting for a seat in a full course, first come first served
+// Java: @Entity class WaitlistEntry { @ManyToOne Student; @ManyToOne Course; Instant joinedAt; }
+type Waitlist struct {
+	gorm.Model
+	StudentID uint      `gorm:"uniqueIndex:idx_waitlist_student_course;not null" json:"student_id"`
+	CourseID  uint      `gorm:"uniqueIndex:idx_waitlist_student_course;index;not null" json:"course_id"`
+	JoinedAt  time.Time `gorm:"not null" json:"joined_at"` // Promotion order
+
+	Student Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Course  Course  `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+}
+
+// Transcript is a student's enrollments plus credit totals and GPA (not a table)
+// Java: a DTO assembled from @Query("SELECT e, c FROM Enrollment e JOIN e.course c WHERE e.student.id = :id")
+type Transcript struct {
+	StudentID        uint               `json:"student_id"`
+	Courses          []TranscriptCourse `json:"courses"`
+	CreditsAttempted int                `json:"credits_attempted"` // Every enrolled course, graded or not
+	CreditsCompleted int                `json:"credits_completed"` // Completed with a passing grade (A-D)
+	GPA              float64            `json:"gpa"`               // Credit-weighted over graded courses; 0 when none
+}
+
+// TranscriptCourse is one line of a Transcript
+type TranscriptCourse struct {
+	CourseID  uint    `json:"course_id"`
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Credits   int     `json:"credits"`
+	Grade     *string `json:"grade"` // null while ungraded
+	Completed bool    `json:"completed"`
+}
+
 // =============================================================================
 // SELF-REFERENTIAL RELATIONSHIP: Category Tree
 // =============================================================================
@@ -0,0 +1,25 @@
+package pretty
+
+import "strings"
+
+// =============================================================================
+// PRETTY - Console output helpers for the demo
+// =============================================================================
+// In Java: String.valueOf('-').repeat(40) / a small ConsoleUtils class
+// Go: strings.Repeat; these return strings (instead of printing) so they're testable
+
+// SectionWidth is the width of the rule under a section title
+const SectionWidth = 40
+
+// Separator returns char repeated n times, e.g. Separator('-', 5) == "-----"
+func Separator(char byte, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat(string(char), n)
+}
+
+// Section returns a demo section heading: a blank line, the title, and a rule under it
+func Section(title string) string {
+	return "\n📌 " + title + "\n" + Separator('-', SectionWidth)
+}
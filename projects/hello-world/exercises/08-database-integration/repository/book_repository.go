@@ -27,15 +27,22 @@ type BookRepository interface {
 	AddTag(bookID uint, tag *model.Tag) error
 	RemoveTag(bookID uint, tagID uint) error
 	ReplaceAllTags(bookID uint, tags []model.Tag) error
+	ReplaceTagsByName(bookID uint, names []string) ([]model.Tag, error) // Creates missing tags
 	GetTags(bookID uint) ([]model.Tag, error)
 
 	// Custom queries
 	FindByAuthorID(authorID uint) ([]model.Book, error)
 	FindByTagName(tagName string) ([]model.Book, error)
+	FindByTagNamePaginated(tagName string, page, pageSize int) ([]model.Book, int64, error)
 	FindByPriceRange(minPrice, maxPrice float64) ([]model.Book, error)
 	SearchByTitle(title string) ([]model.Book, error)
+	SearchByTitlePaginated(title string, page, pageSize int) ([]model.Book, int64, error)
+	FindRelated(bookID uint, limit int) ([]model.RelatedBook, error) // Most shared tags first
 }
 
+// ErrBookNotFound is returned by writes targeting a book that doesn't exist
+var ErrBookNotFound = errors.New("book not found")
+
 type bookRepository struct {
 	db *gorm.DB
 }
@@ -178,6 +185,61 @@ func (r *bookRepository) ReplaceAllTags(bookID uint, tags []model.Tag) error {
 	return r.db.Model(&book).Association("Tags").Replace(tags)
 }
 
+// ReplaceTagsByName sets a book's tags to exactly names, creating tags that don't exist yet
+// Runs in one transaction, so a failure leaves the old tag set untouched
+// Java: @Transactional method doing tagRepository.findByName(...).orElseGet(...) per name
+func (r *bookRepository) ReplaceTagsByName(bookID uint, names []string) ([]model.Tag, error) {
+	tags := make([]model.Tag, 0, len(names))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var book model.Book
+		if err := tx.First(&book, bookID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBookNotFound
+			}
+			return err
+		}
+
+		for _, name := range names {
+			tag, err := findOrRestoreTag(tx, name)
+			if err != nil {
+				return err
+			}
+			tags = append(tags, *tag)
+		}
+
+		return tx.Model(&book).Association("Tags").Replace(tags)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// findOrRestoreTag returns the tag called name, creating it if missing
+// A soft-deleted tag still holds its name in the unique index, so it is restored instead of re-inserted
+func findOrRestoreTag(tx *gorm.DB, name string) (*model.Tag, error) {
+	var tag model.Tag
+	err := tx.Unscoped().Where("name = ?", name).First(&tag).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tag = model.Tag{Name: name}
+		if err := tx.Create(&tag).Error; err != nil {
+			return nil, err
+		}
+		return &tag, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.DeletedAt.Valid {
+		tag.DeletedAt = gorm.DeletedAt{}
+		if err := tx.Unscoped().Model(&tag).Update("deleted_at", nil).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &tag, nil
+}
+
 // GetTags retrieves all tags for a book
 func (r *bookRepository) GetTags(bookID uint) ([]model.Tag, error) {
 	var book model.Book
@@ -212,15 +274,26 @@ func (r *bookRepository) FindByTagName(tagName string) ([]model.Book, error) {
 	//               INNER JOIN book_tags ON book_tags.book_id = books.id
 	//               INNER JOIN tags ON tags.id = book_tags.tag_id
 	//               WHERE tags.name = ?
-	err := r.db.
-		Joins("INNER JOIN book_tags ON book_tags.book_id = books.id").
-		Joins("INNER JOIN tags ON tags.id = book_tags.tag_id").
-		Where("tags.name = ?", tagName).
-		Find(&books).Error
+	err := r.taggedWith(tagName).Find(&books).Error
 
 	return books, err
 }
 
+// FindByTagNamePaginated returns one page of books with the tag plus the total match count
+// Java: Page<Book> findByTagsName(String tagName, Pageable pageable);
+func (r *bookRepository) FindByTagNamePaginated(tagName string, page, pageSize int) ([]model.Book, int64, error) {
+	return paginateBooks(r.taggedWith(tagName), page, pageSize)
+}
+
+// taggedWith is the books-with-tag query shared by the plain and paginated variants
+func (r *bookRepository) taggedWith(tagName string) *gorm.DB {
+	return r.db.
+		Model(&model.Book{}).
+		Joins("INNER JOIN book_tags ON book_tags.book_id = books.id").
+		Joins("INNER JOIN tags ON tags.id = book_tags.tag_id").
+		Where("tags.name = ?", tagName)
+}
+
 // FindByPriceRange finds books within a price range
 // Java: List<Book> findByPriceBetween(BigDecimal min, BigDecimal max);
 func (r *bookRepository) FindByPriceRange(minPrice, maxPrice float64) ([]model.Book, error) {
@@ -243,12 +316,92 @@ func (r *bookRepository) SearchByTitle(title string) ([]model.Book, error) {
 
 	// SQLite uses LIKE which is case-insensitive by default
 	// For other databases, use ILIKE (Postgres) or LOWER()
+	// The term is escaped, so "100%" matches literally instead of as a wildcard
 	err := r.db.
-		Where("title LIKE ?", "%"+title+"%").
+		Where(likeContains("title"), containsPattern(title)).
 		Preload("Tags").
-		Preload("Author").
 		Find(&books).Error
 
 	return books, err
 }
 
+// SearchByTitlePaginated returns one page of title matches plus the total match count
+// Java: Page<Book> findByTitleContainingIgnoreCase(String title, Pageable pageable);
+func (r *bookRepository) SearchByTitlePaginated(title string, page, pageSize int) ([]model.Book, int64, error) {
+	query := r.db.Model(&model.Book{}).Where(likeContains("title"), containsPattern(title))
+	return paginateBooks(query, page, pageSize, "Tags")
+}
+
+// paginateBooks counts query's matches, then loads page (1-based) ordered by ID
+// with the given associations preloaded
+// Same contract as UserRepository.FindAllWithPagination
+func paginateBooks(query *gorm.DB, page, pageSize int, preload ...string) ([]model.Book, int64, error) {
+	var books []model.Book
+	var total int64
+
+	// Session() lets the count and the page query share the conditions
+	query = query.Session(&gorm.Session{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for _, association := range preload {
+		query = query.Preload(association)
+	}
+	offset := (page - 1) * pageSize
+	err := query.Order("books.id").Offset(offset).Limit(pageSize).Find(&books).Error
+	return books, total, err
+}
+
+// FindRelated returns up to limit other books sharing tags with bookID, most shared tags first
+// Ties are broken by book ID so the order is stable
+// Java: @Query("SELECT b2, COUNT(t) FROM Book b1 JOIN b1.tags t JOIN t.books b2 WHERE b1.id = :id AND b2 <> b1 GROUP BY b2 ORDER BY COUNT(t) DESC")
+func (r *bookRepository) FindRelated(bookID uint, limit int) ([]model.RelatedBook, error) {
+	var book model.Book
+	if err := r.db.First(&book, bookID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBookNotFound
+		}
+		return nil, err
+	}
+
+	// Self-join book_tags: pairs of (this book, other book) that share a tag,
+	// then count the pairs per other book
+	var ranked []struct {
+		BookID     uint
+		SharedTags int64
+	}
+	err := r.db.
+		Table("book_tags AS mine").
+		Select("theirs.book_id AS book_id, COUNT(*) AS shared_tags").
+		Joins("JOIN book_tags AS theirs ON theirs.tag_id = mine.tag_id AND theirs.book_id <> mine.book_id").
+		Joins("JOIN tags ON tags.id = mine.tag_id AND "+notDeleted("tags")).
+		Joins("JOIN books ON books.id = theirs.book_id AND "+notDeleted("books")).
+		Where("mine.book_id = ?", bookID).
+		Group("theirs.book_id").
+		Order("shared_tags DESC, theirs.book_id").
+		Limit(limit).
+		Scan(&ranked).Error
+	if err != nil || len(ranked) == 0 {
+		return []model.RelatedBook{}, err
+	}
+
+	ids := make([]uint, len(ranked))
+	for i, row := range ranked {
+		ids[i] = row.BookID
+	}
+	var books []model.Book
+	if err := r.db.Preload("Tags").Find(&books, ids).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]model.Book, len(books))
+	for _, b := range books {
+		byID[b.ID] = b
+	}
+
+	related := make([]model.RelatedBook, 0, len(ranked))
+	for _, row := range ranked {
+		related = append(related, model.RelatedBook{Book: byID[row.BookID], SharedTags: row.SharedTags})
+	}
+	return related, nil
+}
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"errors"
-	"time"
+	"fmt"
 
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
 	"gorm.io/gorm"
@@ -28,7 +28,8 @@ type EnrollmentRepository interface {
 	Enroll(studentID, courseID uint) (*model.Enrollment, error)
 	Unenroll(studentID, courseID uint) error
 	FindByStudentAndCourse(studentID, courseID uint) (*model.Enrollment, error)
-	UpdateGrade(studentID, courseID uint, grade string) error
+	UpdateGrade(studentID, courseID uint, grade string) error        // Overwrites any existing grade (force)
+	UpdateGradeIfUnset(studentID, courseID uint, grade string) error // ErrGradeConflict if already graded
 	MarkCompleted(studentID, courseID uint) error
 
 	// Query operations
@@ -37,15 +38,36 @@ type EnrollmentRepository interface {
 	FindCompletedByStudent(studentID uint) ([]model.Enrollment, error)
 	CountStudentsInCourse(courseID uint) (int64, error)
 	GetAverageGradeForCourse(courseID uint) (float64, error)
+
+	// Waitlist operations
+	JoinWaitlist(studentID, courseID uint) (*model.Waitlist, error)
+	PromoteFromWaitlist(courseID uint) (*model.Enrollment, error)
+	FindWaitlist(courseID uint) ([]model.Waitlist, error)
+
+	StudentTranscript(studentID uint) (model.Transcript, error)
+	FindCourse(courseID uint) (*model.Course, error)
 }
 
+// ErrGradeConflict is returned by UpdateGradeIfUnset when another grader got there first
+var ErrGradeConflict = errors.New("grade already set")
+
+// ErrCourseFull is returned by Enroll when every seat is taken; JoinWaitlist instead
+var ErrCourseFull = errors.New("course is full")
+
 type enrollmentRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	clock Clock // stamps EnrolledAt and JoinedAt
 }
 
 // NewEnrollmentRepository creates a new EnrollmentRepository
 func NewEnrollmentRepository(db *gorm.DB) EnrollmentRepository {
-	return &enrollmentRepository{db: db}
+	return NewEnrollmentRepositoryWithClock(db, SystemClock{})
+}
+
+// NewEnrollmentRepositoryWithClock is NewEnrollmentRepository reading the time from clock
+// Tests pass a FakeClock to get predictable EnrolledAt/JoinedAt values
+func NewEnrollmentRepositoryWithClock(db *gorm.DB, clock Clock) EnrollmentRepository {
+	return &enrollmentRepository{db: db, clock: clock}
 }
 
 // =============================================================================
@@ -74,38 +96,64 @@ func (r *enrollmentRepository) Enroll(studentID, courseID uint) (*model.Enrollme
 	var enrolledCount int64
 	r.db.Model(&model.Enrollment{}).Where("course_id = ?", courseID).Count(&enrolledCount)
 	if int(enrolledCount) >= course.MaxStudents {
-		return nil, errors.New("course is full")
+		return nil, ErrCourseFull
 	}
 
-	// Create enrollment
-	enrollment := &model.Enrollment{
-		StudentID:  studentID,
-		CourseID:   courseID,
-		EnrolledAt: time.Now(),
-		Completed:  false,
+	// *ScheduleConflictError if it clashes with the student's timetable
+	if err := checkSchedule(r.db, studentID, course); err != nil {
+		return nil, err
 	}
 
-	if err := r.db.Create(enrollment).Error; err != nil {
+	// Create enrollment (or revive the one left by an earlier Unenroll)
+	return r.enroll(r.db, studentID, courseID)
+}
+
+// enroll inserts the enrollment row inside tx
+// Unenroll only soft-deletes, and idx_student_course still covers soft-deleted rows,
+// so a student coming back gets their old row revived with a fresh date, no grade, not completed
+func (r *enrollmentRepository) enroll(tx *gorm.DB, studentID, courseID uint) (*model.Enrollment, error) {
+	var enrollment model.Enrollment
+	err := tx.Unscoped().
+		Where("student_id = ? AND course_id = ? AND deleted_at IS NOT NULL", studentID, courseID).
+		First(&enrollment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		enrollment = model.Enrollment{StudentID: studentID, CourseID: courseID, EnrolledAt: r.clock.Now()}
+		if err := tx.Create(&enrollment).Error; err != nil {
+			return nil, err
+		}
+		return &enrollment, nil
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	return enrollment, nil
+	enrollment.DeletedAt = gorm.DeletedAt{}
+	enrollment.EnrolledAt = r.clock.Now()
+	enrollment.Grade = nil
+	enrollment.Completed = false
+	if err := tx.Unscoped().Select("deleted_at", "enrolled_at", "grade", "completed").Save(&enrollment).Error; err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
 }
 
-// Unenroll removes a student from a course
+// Unenroll removes a student from a course and gives the freed seat to the waitlist
 // Java: enrollmentRepository.deleteByStudentIdAndCourseId(studentId, courseId);
 func (r *enrollmentRepository) Unenroll(studentID, courseID uint) error {
-	result := r.db.
-		Where("student_id = ? AND course_id = ?", studentID, courseID).
-		Delete(&model.Enrollment{})
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Where("student_id = ? AND course_id = ?", studentID, courseID).
+			Delete(&model.Enrollment{})
 
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return errors.New("enrollment not found")
-	}
-	return nil
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("enrollment not found")
+		}
+		_, err := r.promote(tx, courseID)
+		return err
+	})
 }
 
 // FindByStudentAndCourse finds a specific enrollment
@@ -124,7 +172,8 @@ func (r *enrollmentRepository) FindByStudentAndCourse(studentID, courseID uint)
 	return &enrollment, err
 }
 
-// UpdateGrade sets the grade for an enrollment
+// UpdateGrade sets the grade for an enrollment, overwriting any existing grade
+// This is the force variant; use UpdateGradeIfUnset so concurrent graders can't clobber each other
 // Java: @Modifying @Query("UPDATE Enrollment e SET e.grade = :grade WHERE e.student.id = :sid AND e.course.id = :cid")
 func (r *enrollmentRepository) UpdateGrade(studentID, courseID uint, grade string) error {
 	// Model() + Where() + Update() for targeted updates
@@ -143,6 +192,33 @@ func (r *enrollmentRepository) UpdateGrade(studentID, courseID uint, grade strin
 	return nil
 }
 
+// UpdateGradeIfUnset sets the grade only while it is still NULL
+// The check and the write are one UPDATE, so of two concurrent graders exactly one wins
+// Java: @Version optimistic locking, or UPDATE ... WHERE grade IS NULL and checking the row count
+func (r *enrollmentRepository) UpdateGradeIfUnset(studentID, courseID uint, grade string) error {
+	result := r.db.
+		Model(&model.Enrollment{}).
+		Where("student_id = ? AND course_id = ? AND grade IS NULL", studentID, courseID).
+		Update("grade", grade)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil
+	}
+
+	// Nothing updated: either there is no such enrollment or it already has a grade
+	existing, err := r.FindByStudentAndCourse(studentID, courseID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.New("enrollment not found")
+	}
+	return fmt.Errorf("%w: current grade is %s", ErrGradeConflict, *existing.Grade)
+}
+
 // MarkCompleted marks an enrollment as completed
 func (r *enrollmentRepository) MarkCompleted(studentID, courseID uint) error {
 	result := r.db.
@@ -208,16 +284,16 @@ func (r *enrollmentRepository) CountStudentsInCourse(courseID uint) (int64, erro
 }
 
 // GetAverageGradeForCourse calculates the average grade for a course
-// Demonstrates raw SQL for complex calculations
+// Demonstrates a hand-written SQL expression for complex calculations
 // Java: @Query("SELECT AVG(CASE WHEN e.grade = 'A' THEN 4.0 ... END) FROM Enrollment e WHERE e.course.id = :cid")
 func (r *enrollmentRepository) GetAverageGradeForCourse(courseID uint) (float64, error) {
 	var result struct {
 		Average float64
 	}
-	// Raw SQL for complex calculations
-	// GORM supports raw queries when the query builder isn't sufficient
-	err := r.db.Raw(`
-		SELECT AVG(
+	// selectLive rather than db.Raw, so soft-deleted enrollments are excluded
+	// by GORM instead of by a hand-written "deleted_at IS NULL"
+	err := selectLive(r.db, &model.Enrollment{}, `
+		AVG(
 			CASE grade
 				WHEN 'A' THEN 4.0
 				WHEN 'B' THEN 3.0
@@ -226,10 +302,176 @@ func (r *enrollmentRepository) GetAverageGradeForCourse(courseID uint) (float64,
 				WHEN 'F' THEN 0.0
 				ELSE NULL
 			END
-		) as average
-		FROM enrollments
-		WHERE course_id = ? AND grade IS NOT NULL AND deleted_at IS NULL
-	`, courseID).Scan(&result).Error
+		) as average`).
+		Where("course_id = ? AND grade IS NOT NULL", courseID).
+		Scan(&result).Error
 
 	return result.Average, err
 }
+
+// gradePoints maps letter grades to GPA points (same scale as GetAverageGradeForCourse)
+var gradePoints = map[string]float64{"A": 4.0, "B": 3.0, "C": 2.0, "D": 1.0, "F": 0.0}
+
+// StudentTranscript returns a student's courses with credits attempted/completed and GPA
+// One query joins courses for the credit weights; the totals are computed in Go
+// Ungraded enrollments count as attempted but not toward GPA
+// Java: a @Transactional(readOnly = true) service method building a TranscriptDTO
+func (r *enrollmentRepository) StudentTranscript(studentID uint) (model.Transcript, error) {
+	transcript := model.Transcript{StudentID: studentID, Courses: []model.TranscriptCourse{}}
+
+	var student model.Student
+	if err := r.db.First(&student, studentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return transcript, errors.New("student not found")
+		}
+		return transcript, err
+	}
+
+	err := r.db.
+		Model(&model.Enrollment{}).
+		Select("courses.id AS course_id, courses.code, courses.name, courses.credits, enrollments.grade, enrollments.completed").
+		Joins("JOIN courses ON courses.id = enrollments.course_id AND "+notDeleted("courses")).
+		Where("enrollments.student_id = ?", studentID).
+		Order("enrollments.enrolled_at, courses.code").
+		Scan(&transcript.Courses).Error
+	if err != nil {
+		return transcript, err
+	}
+
+	var points float64
+	var gradedCredits int
+	for _, c := range transcript.Courses {
+		transcript.CreditsAttempted += c.Credits
+		if c.Grade == nil {
+			continue
+		}
+		p, ok := gradePoints[*c.Grade]
+		if !ok {
+			continue // unknown letter: treat like ungraded rather than guess
+		}
+		points += p * float64(c.Credits)
+		gradedCredits += c.Credits
+		if c.Completed && *c.Grade != "F" {
+			transcript.CreditsCompleted += c.Credits
+		}
+	}
+	if gradedCredits > 0 {
+		transcript.GPA = points / float64(gradedCredits)
+	}
+	return transcript, nil
+}
+
+// FindCourse retrieves a course by primary key, or nil if there is none
+func (r *enrollmentRepository) FindCourse(courseID uint) (*model.Course, error) {
+	var course model.Course
+	err := r.db.First(&course, courseID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+// =============================================================================
+// WAITLIST OPERATIONS
+// =============================================================================
+
+// JoinWaitlist queues a student for a full course
+// Students with a seat, or already waiting, can't join
+func (r *enrollmentRepository) JoinWaitlist(studentID, courseID uint) (*model.Waitlist, error) {
+	var course model.Course
+	if err := r.db.First(&course, courseID).Error; err != nil {
+		return nil, errors.New("course not found")
+	}
+
+	var enrolled int64
+	r.db.Model(&model.Enrollment{}).Where("student_id = ? AND course_id = ?", studentID, courseID).Count(&enrolled)
+	if enrolled > 0 {
+		return nil, errors.New("student is already enrolled in this course")
+	}
+
+	var enrolledCount int64
+	r.db.Model(&model.Enrollment{}).Where("course_id = ?", courseID).Count(&enrolledCount)
+	if int(enrolledCount) < course.MaxStudents {
+		return nil, errors.New("course has open seats, enroll instead")
+	}
+
+	var waiting int64
+	r.db.Model(&model.Waitlist{}).Where("student_id = ? AND course_id = ?", studentID, courseID).Count(&waiting)
+	if waiting > 0 {
+		return nil, errors.New("student is already on the waitlist")
+	}
+
+	entry := &model.Waitlist{StudentID: studentID, CourseID: courseID, JoinedAt: r.clock.Now()}
+	if err := r.db.Create(entry).Error; err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// PromoteFromWaitlist enrolls the earliest waitlisted student if a seat is free
+// Returns nil, nil when the course is still full or nobody is waiting
+func (r *enrollmentRepository) PromoteFromWaitlist(courseID uint) (*model.Enrollment, error) {
+	var promoted *model.Enrollment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		promoted, err = r.promote(tx, courseID)
+		return err
+	})
+	return promoted, err
+}
+
+// FindWaitlist lists a course's waitlist in promotion order
+func (r *enrollmentRepository) FindWaitlist(courseID uint) ([]model.Waitlist, error) {
+	var entries []model.Waitlist
+	err := r.db.
+		Where("course_id = ?", courseID).
+		Order("joined_at, id").
+		Preload("Student").
+		Find(&entries).Error
+	return entries, err
+}
+
+// promote moves the first waitlisted student whose timetable allows it into the course inside tx
+// Students whose schedule clashes are skipped but keep their place, in case they drop the other course
+func (r *enrollmentRepository) promote(tx *gorm.DB, courseID uint) (*model.Enrollment, error) {
+	var course model.Course
+	if err := tx.First(&course, courseID).Error; err != nil {
+		return nil, err
+	}
+	var enrolledCount int64
+	if err := tx.Model(&model.Enrollment{}).Where("course_id = ?", courseID).Count(&enrolledCount).Error; err != nil {
+		return nil, err
+	}
+	if int(enrolledCount) >= course.MaxStudents {
+		return nil, nil
+	}
+
+	var waiting []model.Waitlist
+	if err := tx.Where("course_id = ?", courseID).Order("joined_at, id").Find(&waiting).Error; err != nil {
+		return nil, err
+	}
+	for _, next := range waiting {
+		err := checkSchedule(tx, next.StudentID, course)
+		var conflict *ScheduleConflictError
+		if errors.As(err, &conflict) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		enrollment, err := r.enroll(tx, next.StudentID, courseID)
+		if err != nil {
+			return nil, err
+		}
+		// Hard delete so the unique index doesn't block joining a waitlist again later
+		if err := tx.Unscoped().Delete(&next).Error; err != nil {
+			return nil, err
+		}
+		return enrollment, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import "strings"
+
+// =============================================================================
+// LIKE PATTERNS FROM USER INPUT
+// =============================================================================
+// "%" and "_" are wildcards inside LIKE, so a search for "100%" would match
+// "1000 Recipes" and "_" alone would match everything.
+// Java: Spring Data escapes these for you in findByXxxContaining (EscapeCharacter.DEFAULT)
+//
+// Pattern: Where(likeContains("title"), containsPattern(q))
+
+// likeEscape is the escape character declared in every LIKE built here
+const likeEscape = `\`
+
+// likeReplacer escapes the escape character first, then the wildcards
+var likeReplacer = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+
+// escapeLike makes every character of s match literally inside a LIKE pattern
+func escapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+// containsPattern is the LIKE pattern matching s anywhere in the column
+func containsPattern(s string) string {
+	return "%" + escapeLike(s) + "%"
+}
+
+// likeContains is the condition "column LIKE ? ESCAPE '\'" for use with containsPattern
+func likeContains(column string) string {
+	return column + " LIKE ? ESCAPE '" + likeEscape + "'"
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// SCHEDULE CONFLICTS
+// =============================================================================
+// In Java/Spring: a check inside the @Transactional enroll method
+// Go: Checked in the repository so Enroll and waitlist promotion share it,
+// inside the same transaction that inserts the enrollment
+
+// ScheduleConflictError reports which existing course overlaps the requested one
+// Check with errors.As to show both schedules to the user
+type ScheduleConflictError struct {
+	Course        model.Course // The course the student tried to enroll in
+	ConflictsWith model.Course // An existing enrollment's course
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("%s (%s) conflicts with %s (%s)",
+		e.Course.Code, describeSchedule(e.Course), e.ConflictsWith.Code, describeSchedule(e.ConflictsWith))
+}
+
+// checkSchedule returns a *ScheduleConflictError if course overlaps any of the
+// student's current enrollments, or an error if a schedule can't be parsed
+func checkSchedule(tx *gorm.DB, studentID uint, course model.Course) error {
+	requested, err := parseSchedule(course)
+	if err != nil || requested == nil {
+		return err
+	}
+
+	var current []model.Enrollment
+	if err := tx.Where("student_id = ?", studentID).Preload("Course").Find(&current).Error; err != nil {
+		return fmt.Errorf("failed to load enrollments: %w", err)
+	}
+	for _, enrollment := range current {
+		existing, err := parseSchedule(enrollment.Course)
+		if err != nil {
+			return err
+		}
+		if existing != nil && requested.overlaps(existing) {
+			return &ScheduleConflictError{Course: course, ConflictsWith: enrollment.Course}
+		}
+	}
+	return nil
+}
+
+// =============================================================================
+// SCHEDULE PARSING
+// =============================================================================
+
+// schedule is a course's weekly slot in minutes since midnight
+type schedule struct {
+	days       map[time.Weekday]bool
+	start, end int
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseSchedule returns nil for unscheduled courses
+func parseSchedule(c model.Course) (*schedule, error) {
+	if c.StartTime == "" && c.EndTime == "" && c.DaysOfWeek == "" {
+		return nil, nil
+	}
+
+	start, err := parseClock(c.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("course %s: invalid start time: %w", c.Code, err)
+	}
+	end, err := parseClock(c.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("course %s: invalid end time: %w", c.Code, err)
+	}
+	if end <= start {
+		return nil, fmt.Errorf("course %s: end time %s is not after start time %s", c.Code, c.EndTime, c.StartTime)
+	}
+
+	days := make(map[time.Weekday]bool)
+	for _, d := range strings.Split(c.DaysOfWeek, ",") {
+		name := strings.ToLower(strings.TrimSpace(d))
+		if len(name) > 3 {
+			name = name[:3] // "Monday" works as well as "Mon"
+		}
+		day, ok := weekdays[name]
+		if !ok {
+			return nil, fmt.Errorf("course %s: unknown day %q", c.Code, d)
+		}
+		days[day] = true
+	}
+	return &schedule{days: days, start: start, end: end}, nil
+}
+
+// parseClock converts "HH:MM" to minutes since midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// overlaps reports whether both meet on a shared day at the same time
+// Back-to-back slots (one ends when the other starts) don't overlap
+func (s *schedule) overlaps(other *schedule) bool {
+	for day := range s.days {
+		if other.days[day] {
+			return s.start < other.end && other.start < s.end
+		}
+	}
+	return false
+}
+
+func describeSchedule(c model.Course) string {
+	return fmt.Sprintf("%s %s-%s", c.DaysOfWeek, c.StartTime, c.EndTime)
+}
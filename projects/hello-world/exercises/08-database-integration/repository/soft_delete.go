@@ -0,0 +1,27 @@
+package repository
+
+import "gorm.io/gorm"
+
+// =============================================================================
+// SOFT DELETE IN HAND-WRITTEN SQL
+// =============================================================================
+// GORM adds "deleted_at IS NULL" to every query it builds for a gorm.Model type,
+// but db.Raw() bypasses that, just like a native query bypasses Hibernate's @Where.
+//
+// Pattern:
+//   - Aggregates: selectLive(db, &model.X{}, "AVG(...)").Where(...) instead of db.Raw
+//   - Joins / raw SQL: AND notDeleted("table") for every soft-deletable table
+//
+// The test database rejects raw queries on soft-deletable tables that never
+// mention deleted_at, so a forgotten predicate fails the tests.
+
+// selectLive selects selectExpr from model's table, keeping GORM's soft-delete filter
+// Java: a JPQL aggregate, which still honours @Where unlike a nativeQuery
+func selectLive(db *gorm.DB, model interface{}, selectExpr string) *gorm.DB {
+	return db.Model(model).Select(selectExpr)
+}
+
+// notDeleted is the soft-delete predicate for table (or its alias) in hand-written SQL
+func notDeleted(table string) string {
+	return table + ".deleted_at IS NULL"
+}
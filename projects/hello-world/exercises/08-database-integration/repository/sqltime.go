@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// nullTime scans a possibly-NULL timestamp produced by an aggregate like MAX(created_at)
+// Postgres/MySQL hand back time.Time, but SQLite returns TEXT because
+// aggregates lose the column's declared DATETIME type
+type nullTime struct {
+	Time *time.Time
+}
+
+// Layouts the SQLite driver writes timestamps in
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Scan implements sql.Scanner
+func (n *nullTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		n.Time = nil
+		return nil
+	case time.Time:
+		n.Time = &v
+		return nil
+	case []byte:
+		return n.parse(string(v))
+	case string:
+		return n.parse(v)
+	}
+	return fmt.Errorf("cannot scan %T into a timestamp", value)
+}
+
+// Value implements driver.Valuer (GORM needs both to treat the struct as a column)
+func (n nullTime) Value() (driver.Value, error) {
+	if n.Time == nil {
+		return nil, nil
+	}
+	return *n.Time, nil
+}
+
+func (n *nullTime) parse(s string) error {
+	for _, layout := range sqliteTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			n.Time = &t
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognised timestamp %q", s)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// STATS REPOSITORY - Row counts for the admin dashboard
+// =============================================================================
+// In Java/Spring: several repository.count() calls fanned out with CompletableFuture.allOf
+// Go: one COUNT(*) per table and state, run concurrently with errgroup
+//
+// The first failing count cancels the context of the others and its error is returned.
+
+// StatsRepository reports table sizes
+type StatsRepository interface {
+	Counts(ctx context.Context) (model.DatabaseStats, error)
+}
+
+type statsRepository struct {
+	db *gorm.DB
+}
+
+// NewStatsRepository creates a new stats repository
+func NewStatsRepository(db *gorm.DB) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+// Counts returns live and soft-deleted row counts for the main tables
+func (r *statsRepository) Counts(ctx context.Context) (model.DatabaseStats, error) {
+	var stats model.DatabaseStats
+	tables := []struct {
+		model interface{}
+		count *model.TableCount
+	}{
+		{&model.User{}, &stats.Users},
+		{&model.Book{}, &stats.Books},
+		{&model.Course{}, &stats.Courses},
+		{&model.Enrollment{}, &stats.Enrollments},
+		{&model.Post{}, &stats.Posts},
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	db := r.db.WithContext(ctx)
+	for _, t := range tables {
+		// GORM's default scope leaves soft-deleted rows out of the live count
+		g.Go(func() error {
+			return db.Model(t.model).Count(&t.count.Live).Error
+		})
+		g.Go(func() error {
+			return db.Unscoped().Model(t.model).Where("deleted_at IS NOT NULL").Count(&t.count.Deleted).Error
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return model.DatabaseStats{}, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// TAG REPOSITORY - Aggregates across the book_tags join table
+// =============================================================================
+
+// TagRepository defines operations for Tag entity
+type TagRepository interface {
+	Create(tag *model.Tag) error
+
+	// Aggregations
+	AuthorCountByTag() (map[string]int64, error)
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new TagRepository
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// Create inserts a new tag
+func (r *tagRepository) Create(tag *model.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+// AuthorCountByTag returns, per tag name, how many distinct authors have a book with that tag
+// Tags with no (live) books map to 0
+// Java: @Query("SELECT t.name, COUNT(DISTINCT b.author.id) FROM Tag t LEFT JOIN t.books b GROUP BY t.name")
+func (r *tagRepository) AuthorCountByTag() (map[string]int64, error) {
+	var rows []struct {
+		Tag     string
+		Authors int64
+	}
+
+	// LEFT JOINs keep unused tags; the books.deleted_at check sits in the ON clause
+	// so a soft-deleted book drops out without dropping its tag
+	// Model(&model.Tag{}) adds "tags.deleted_at IS NULL" automatically
+	err := r.db.Model(&model.Tag{}).
+		Select("tags.name AS tag, COUNT(DISTINCT books.author_id) AS authors").
+		Joins("LEFT JOIN book_tags ON book_tags.tag_id = tags.id").
+		Joins("LEFT JOIN books ON books.id = book_tags.book_id AND books.deleted_at IS NULL").
+		Group("tags.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Tag] = row.Authors
+	}
+	return counts, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// MULTI-TENANCY
+// =============================================================================
+// In Java/Spring: Hibernate's @TenantId / @Filter("tenantFilter") enabled per session
+// Go: The tenant travels on the context; a tenant repository adds
+// "users.tenant_id = ?" to every query and stamps it on every insert
+
+type tenantKey struct{}
+
+// ErrNoTenant is returned when a tenant repository is requested without a tenant on the context
+var ErrNoTenant = errors.New("no tenant in context")
+
+// WithTenant returns ctx carrying tenantID
+// Java: TenantContext.setCurrentTenant(tenantId)
+func WithTenant(ctx context.Context, tenantID uint) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant set by WithTenant
+func TenantFromContext(ctx context.Context) (uint, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(uint)
+	return tenantID, ok
+}
+
+// NewTenantUserRepository returns a UserRepository that only sees the tenant from ctx
+// Users of other tenants are invisible: lookups return nil and writes return ErrUserNotFound
+func NewTenantUserRepository(ctx context.Context, db *gorm.DB) (UserRepository, error) {
+	tenantID, ok := TenantFromContext(ctx)
+	if !ok {
+		return nil, ErrNoTenant
+	}
+	return &userRepository{db: db.WithContext(ctx), tenantID: &tenantID}, nil
+}
+
+// scope restricts a users query to the repository's tenant (no-op when unscoped)
+// The column is table-qualified so it stays unambiguous in joins
+func (r *userRepository) scope(db *gorm.DB) *gorm.DB {
+	if r.tenantID == nil {
+		return db
+	}
+	return db.Where("users.tenant_id = ?", *r.tenantID)
+}
+
+// users is the scoped starting point for queries on the users table
+func (r *userRepository) users() *gorm.DB {
+	return r.scope(r.db)
+}
@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/querybuilder"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/retry"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // =============================================================================
@@ -18,27 +24,57 @@ import (
 type UserRepository interface {
 	// Basic CRUD - like JpaRepository methods
 	Create(user *model.User) error
+	CreateMany(users []model.User, batchSize int) error // Bulk insert
 	FindByID(id uint) (*model.User, error)
-	FindByIDWithProfile(id uint) (*model.User, error)  // Eager load profile
-	FindByIDWithPosts(id uint) (*model.User, error)    // Eager load posts
+	FindByIDWithProfile(id uint) (*model.User, error) // Eager load profile
+	FindByIDWithPosts(id uint) (*model.User, error)   // Eager load posts
 	FindByEmail(email string) (*model.User, error)
-	FindAll() ([]model.User, error)
+	FindByPublicID(publicID string) (*model.User, error) // UUID lookup for external references
+	FindByIDs(ids []uint) ([]model.User, error)          // One IN query; missing ids are skipped
+	FindAll(ctx context.Context) ([]model.User, error)   // ctx cancels the query, e.g. when the client disconnects
+	EachUser(fn func(model.User) error) error            // Streams users one row at a time; stops at fn's first error
 	FindAllWithPagination(page, pageSize int) ([]model.User, int64, error)
+	FindByQuery(ctx context.Context, q *querybuilder.Query) ([]model.User, int64, error) // Filtered, sorted page; q.PageSize must be set
 	Update(user *model.User) error
+	UpsertProfile(userID uint, bio, avatarURL, website string) (*model.Profile, error) // Find-or-create by user id
+	UpsertByEmail(user *model.User) error                                              // Insert, or update the row with the same email
+	UpdateRole(id uint, role string) error                                             // ErrUserNotFound if no such user
 	Delete(id uint) error
-	HardDelete(id uint) error  // Permanent delete
+	DeleteMany(ids []uint) (deleted []uint, err error)  // Soft delete; returns the ids that existed
+	HardDelete(id uint) error                           // Permanent delete
+	PurgeDeletedBefore(cutoff time.Time) (int64, error) // Permanently remove users soft-deleted before cutoff
 
 	// Custom queries - like @Query in Spring Data
 	FindByAgeGreaterThan(age int) ([]model.User, error)
-	FindByNameContaining(name string) ([]model.User, error)
+	FindByNameContaining(ctx context.Context, name string) ([]model.User, error)
 	CountByAge(age int) (int64, error)
 	ExistsByEmail(email string) (bool, error)
+	FindWithoutPosts() ([]model.User, error)
+	PostStats(userID uint) (*model.PostStats, error)
 }
 
+// UserQueryFields are the user fields list endpoints may filter and sort on
+// Anything else in filter[...] or sort= is rejected by querybuilder.Parse
+var UserQueryFields = querybuilder.Spec{
+	Fields: map[string]string{
+		"id":         "users.id",
+		"name":       "users.name",
+		"email":      "users.email",
+		"age":        "users.age",
+		"created_at": "users.created_at",
+	},
+	DefaultSort: "id",
+}
+
+// ErrUserNotFound is returned by writes targeting a user that doesn't exist
+// (lookups return nil, nil instead, like Optional.empty())
+var ErrUserNotFound = errors.New("user not found")
+
 // userRepository implements UserRepository
 // Private struct - only expose through interface
 type userRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	tenantID *uint // set by NewTenantUserRepository; nil sees every tenant
 }
 
 // NewUserRepository creates a new UserRepository instance
@@ -56,7 +92,34 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 func (r *userRepository) Create(user *model.User) error {
 	// GORM's Create() is like JPA's persist()
 	// It will also create associated entities if present (cascade)
-	return r.db.Create(user).Error
+	// Transient errors (e.g. SQLite "database is locked") are retried with backoff
+	if r.tenantID != nil {
+		user.TenantID = *r.tenantID
+	}
+	return retry.Do(context.Background(), 3, 50*time.Millisecond, func() error {
+		return r.db.Create(user).Error
+	})
+}
+
+// CreateMany inserts users in batches of batchSize rows per INSERT
+// All-or-nothing: a failing batch rolls back the batches before it
+// Java: repository.saveAll(users) with hibernate.jdbc.batch_size
+func (r *userRepository) CreateMany(users []model.User, batchSize int) error {
+	if len(users) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+	if r.tenantID != nil {
+		for i := range users {
+			users[i].TenantID = *r.tenantID
+		}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&users, batchSize).Error
+	})
 }
 
 // FindByID retrieves a user by primary key
@@ -66,7 +129,7 @@ func (r *userRepository) FindByID(id uint) (*model.User, error) {
 
 	// First() finds first record matching condition, ordered by primary key
 	// Returns ErrRecordNotFound if not found
-	err := r.db.First(&user, id).Error
+	err := r.users().First(&user, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // Return nil, nil for "not found" (like Optional.empty())
@@ -83,7 +146,7 @@ func (r *userRepository) FindByIDWithProfile(id uint) (*model.User, error) {
 
 	// Preload() is like JPA's eager fetch or @EntityGraph
 	// It executes a separate query for the association
-	err := r.db.Preload("Profile").First(&user, id).Error
+	err := r.users().Preload("Profile").First(&user, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -99,7 +162,7 @@ func (r *userRepository) FindByIDWithPosts(id uint) (*model.User, error) {
 	var user model.User
 
 	// Multiple Preload() calls for multiple associations
-	err := r.db.Preload("Posts").Preload("Profile").First(&user, id).Error
+	err := r.users().Preload("Posts").Preload("Profile").First(&user, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -116,7 +179,7 @@ func (r *userRepository) FindByEmail(email string) (*model.User, error) {
 
 	// Where() is like JPQL: SELECT u FROM User u WHERE u.email = :email
 	// Use ? placeholder to prevent SQL injection (like prepared statements)
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.users().Where("email = ?", email).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -126,17 +189,68 @@ func (r *userRepository) FindByEmail(email string) (*model.User, error) {
 	return &user, nil
 }
 
+// FindByPublicID finds a user by the UUID exposed to clients
+// Java: Optional<User> findByPublicId(UUID publicId);
+func (r *userRepository) FindByPublicID(publicID string) (*model.User, error) {
+	var user model.User
+	err := r.users().Where("public_id = ?", publicID).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByIDs retrieves the users with the given ids, ordered by id
+// Ids with no (live) user are simply absent from the result
+// Java: repository.findAllById(ids)
+func (r *userRepository) FindByIDs(ids []uint) ([]model.User, error) {
+	var users []model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.users().Where("users.id IN ?", ids).Order("users.id").Find(&users).Error
+	return users, err
+}
+
 // FindAll retrieves all users
 // Java: repository.findAll()
-func (r *userRepository) FindAll() ([]model.User, error) {
+func (r *userRepository) FindAll(ctx context.Context) ([]model.User, error) {
 	var users []model.User
 
 	// Find() with no conditions returns all records
 	// Note: Soft-deleted records are automatically excluded (WHERE deleted_at IS NULL)
-	err := r.db.Find(&users).Error
+	// WithContext: the query is interrupted once ctx is cancelled
+	// Java: a JDBC Statement.cancel() when the request is aborted
+	err := r.users().WithContext(ctx).Find(&users).Error
 	return users, err
 }
 
+// EachUser calls fn for every user, in id order, reading one row at a time
+// Unlike FindAll, memory stays flat however many users there are
+// Iteration stops at the first error from fn, which is returned as-is
+// Java: try (Stream<User> s = repository.streamAll()) { s.forEach(...); }
+func (r *userRepository) EachUser(fn func(model.User) error) error {
+	rows, err := r.users().Model(&model.User{}).Order("users.id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user model.User
+		if err := r.db.ScanRows(rows, &user); err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // FindAllWithPagination retrieves users with pagination
 // Java: repository.findAll(PageRequest.of(page, size))
 func (r *userRepository) FindAllWithPagination(page, pageSize int) ([]model.User, int64, error) {
@@ -144,37 +258,199 @@ func (r *userRepository) FindAllWithPagination(page, pageSize int) ([]model.User
 	var total int64
 
 	// Count total records first
-	r.db.Model(&model.User{}).Count(&total)
+	r.users().Model(&model.User{}).Count(&total)
 
 	// Offset = (page - 1) * pageSize for 1-based page numbers
 	// Limit = pageSize
 	offset := (page - 1) * pageSize
-	err := r.db.Offset(offset).Limit(pageSize).Find(&users).Error
+	err := r.users().Offset(offset).Limit(pageSize).Find(&users).Error
 
 	return users, total, err
 }
 
+// FindByQuery returns one page of the users matching q, plus the total match count
+// Java: repository.findAll(specification, pageable)
+func (r *userRepository) FindByQuery(ctx context.Context, q *querybuilder.Query) ([]model.User, int64, error) {
+	var users []model.User
+	var total int64
+
+	// Session() lets the count and the page query share the conditions (and ctx)
+	query := q.Apply(r.users().WithContext(ctx).Model(&model.User{})).Session(&gorm.Session{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := q.Paginate(query).Find(&users).Error
+	return users, total, err
+}
+
 // Update saves changes to an existing user
 // Java: repository.save(user) for existing entity
 func (r *userRepository) Update(user *model.User) error {
 	// Save() updates all fields (like merge in JPA)
 	// Use Updates() for partial updates
+	if r.tenantID != nil {
+		// Save upserts, so check ownership first or another tenant's row could be overwritten
+		var count int64
+		if err := r.users().Model(&model.User{}).Where("id = ?", user.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrUserNotFound
+		}
+		user.TenantID = *r.tenantID
+	}
 	return r.db.Save(user).Error
 }
 
+// UpsertProfile sets the fields of userID's profile, creating the row if the user has none
+// Older users may predate profiles; saving user.Profile on them would insert a second row
+// (or trip the unique index), so look the profile up by user id instead
+// Returns ErrUserNotFound when there is no such user
+// Java: @Transactional find-or-create: profileRepo.findByUserId(id).orElseGet(() -> new Profile(user))
+func (r *userRepository) UpsertProfile(userID uint, bio, avatarURL, website string) (*model.Profile, error) {
+	var profile model.Profile
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := r.scope(tx.Model(&model.User{})).Where("id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrUserNotFound
+		}
+
+		// Unscoped: a soft-deleted profile still holds the user_id unique index, so revive it
+		if err := tx.Unscoped().Where(model.Profile{UserID: userID}).FirstOrInit(&profile).Error; err != nil {
+			return err
+		}
+		profile.DeletedAt = gorm.DeletedAt{}
+		profile.Bio = bio
+		profile.AvatarURL = avatarURL
+		profile.Website = website
+		return tx.Save(&profile).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// UpdateRole sets the role of user id, returning ErrUserNotFound if there is no such user
+// Java: @Modifying @Query("UPDATE User u SET u.role = :role WHERE u.id = :id")
+func (r *userRepository) UpdateRole(id uint, role string) error {
+	result := r.users().Model(&model.User{}).Where("users.id = ?", id).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// UpsertByEmail inserts user, or updates name and age of the user with the same email
+// One INSERT ... ON CONFLICT (tenant_id, email) DO UPDATE statement, so concurrent sync jobs
+// can't both miss in a find-then-create and race on the unique index
+// Emails are unique per tenant, so a tenant repository only ever matches its own users
+// A soft-deleted user with that email is revived; its id and public_id are kept
+// and copied back into user
+// Java: Hibernate has no portable upsert; @Query(nativeQuery = true) with INSERT ... ON CONFLICT
+func (r *userRepository) UpsertByEmail(user *model.User) error {
+	if r.tenantID != nil {
+		user.TenantID = *r.tenantID
+	}
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "email"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "age", "updated_at", "deleted_at"}),
+	}
+	if err := r.db.Clauses(onConflict).Create(user).Error; err != nil {
+		return err
+	}
+
+	// RETURNING only hands back the id, so reload the surviving row for public_id and timestamps
+	var saved model.User
+	if err := r.db.Where("tenant_id = ? AND email = ?", user.TenantID, user.Email).First(&saved).Error; err != nil {
+		return err
+	}
+	*user = saved
+	return nil
+}
+
 // Delete performs soft delete (sets deleted_at)
 // Java: Custom implementation with @Where clause
 func (r *userRepository) Delete(id uint) error {
 	// Delete() with gorm.Model performs soft delete
 	// The record stays in DB but has deleted_at set
-	return r.db.Delete(&model.User{}, id).Error
+	return r.users().Delete(&model.User{}, id).Error
+}
+
+// DeleteMany soft-deletes every live user in ids in one transaction
+// Ids that don't exist (or are already deleted) are skipped; the rest are returned
+// Java: @Modifying @Query("UPDATE User u SET u.deletedAt = CURRENT_TIMESTAMP WHERE u.id IN :ids")
+func (r *userRepository) DeleteMany(ids []uint) ([]uint, error) {
+	var found []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.scope(tx.Model(&model.User{})).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return nil
+		}
+		return tx.Delete(&model.User{}, found).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
 }
 
-// HardDelete permanently removes a user from database
+// HardDelete permanently removes a user (soft-deleted or not) and the rows they own
+// Returns ErrUserNotFound when there is no such user
 // Java: @Query with native delete or custom implementation
 func (r *userRepository) HardDelete(id uint) error {
-	// Unscoped() bypasses soft delete and actually removes the record
-	return r.db.Unscoped().Delete(&model.User{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := r.scope(tx.Unscoped().Model(&model.User{})).Where("id = ?", id).Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return ErrUserNotFound
+		}
+		_, err = purgeUsers(tx, ids)
+		return err
+	})
+}
+
+// PurgeDeletedBefore permanently removes users soft-deleted before cutoff
+// Java: a scheduled @Modifying @Query("DELETE FROM User u WHERE u.deletedAt < :cutoff")
+func (r *userRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Unscoped() bypasses the automatic "deleted_at IS NULL" filter
+		var ids []uint
+		err := r.scope(tx.Unscoped().Model(&model.User{})).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		purged, err = purgeUsers(tx, ids)
+		return err
+	})
+	return purged, err
+}
+
+// purgeUsers hard-deletes users plus their profile, posts and comments
+// The models declare ON DELETE CASCADE, but SQLite only enforces foreign keys
+// when PRAGMA foreign_keys is on, so dependents are removed explicitly
+func purgeUsers(tx *gorm.DB, ids []uint) (int64, error) {
+	for _, dependent := range []interface{}{&model.Comment{}, &model.Post{}, &model.Profile{}} {
+		if err := tx.Unscoped().Where("user_id IN ?", ids).Delete(dependent).Error; err != nil {
+			return 0, err
+		}
+	}
+	result := tx.Unscoped().Delete(&model.User{}, ids)
+	return result.RowsAffected, result.Error
 }
 
 // =============================================================================
@@ -187,18 +463,18 @@ func (r *userRepository) FindByAgeGreaterThan(age int) ([]model.User, error) {
 	var users []model.User
 
 	// Where() with comparison operator
-	err := r.db.Where("age > ?", age).Find(&users).Error
+	err := r.users().Where("age > ?", age).Find(&users).Error
 	return users, err
 }
 
 // FindByNameContaining finds users whose name contains the search string
 // Java: List<User> findByNameContaining(String name); or @Query with LIKE
-func (r *userRepository) FindByNameContaining(name string) ([]model.User, error) {
+func (r *userRepository) FindByNameContaining(ctx context.Context, name string) ([]model.User, error) {
 	var users []model.User
 
 	// LIKE query with wildcards
-	// %name% matches anywhere in the string
-	err := r.db.Where("name LIKE ?", "%"+name+"%").Find(&users).Error
+	// %name% matches anywhere in the string; % and _ inside name are escaped
+	err := r.users().WithContext(ctx).Where(likeContains("name"), containsPattern(name)).Find(&users).Error
 	return users, err
 }
 
@@ -208,7 +484,7 @@ func (r *userRepository) CountByAge(age int) (int64, error) {
 	var count int64
 
 	// Model() specifies the table, Count() executes SELECT COUNT(*)
-	err := r.db.Model(&model.User{}).Where("age = ?", age).Count(&count).Error
+	err := r.users().Model(&model.User{}).Where("age = ?", age).Count(&count).Error
 	return count, err
 }
 
@@ -216,7 +492,42 @@ func (r *userRepository) CountByAge(age int) (int64, error) {
 // Java: boolean existsByEmail(String email);
 func (r *userRepository) ExistsByEmail(email string) (bool, error) {
 	var count int64
-	err := r.db.Model(&model.User{}).Where("email = ?", email).Count(&count).Error
+	err := r.users().Model(&model.User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
 
+// FindWithoutPosts finds users who have never posted (soft-deleted posts don't count)
+// Java: @Query("SELECT u FROM User u LEFT JOIN u.posts p WHERE p.id IS NULL")
+func (r *userRepository) FindWithoutPosts() ([]model.User, error) {
+	var users []model.User
+
+	// Anti-join: the deleted_at check must sit in the ON clause, not WHERE,
+	// otherwise users whose posts were all deleted would be filtered out
+	err := r.users().
+		Joins("LEFT JOIN posts ON posts.user_id = users.id AND posts.deleted_at IS NULL").
+		Where("posts.id IS NULL").
+		Find(&users).Error
+	return users, err
+}
+
+// PostStats returns a user's post count and latest post time in one aggregate query
+// Java: @Query("SELECT COUNT(p), MAX(p.createdAt) FROM Post p WHERE p.user.id = :userId")
+func (r *userRepository) PostStats(userID uint) (*model.PostStats, error) {
+	var row struct {
+		PostCount  int64
+		LastPostAt nullTime
+	}
+
+	// COUNT is 0 and MAX is NULL when there are no rows, so no special casing needed
+	query := r.db.Model(&model.Post{}).
+		Select("COUNT(*) AS post_count, MAX(created_at) AS last_post_at").
+		Where("user_id = ?", userID)
+	if r.tenantID != nil {
+		query = query.Where("user_id IN (?)", r.users().Model(&model.User{}).Select("id"))
+	}
+	err := query.Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &model.PostStats{PostCount: row.PostCount, LastPostAt: row.LastPostAt.Time}, nil
+}
@@ -0,0 +1,64 @@
+package response
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// =============================================================================
+// API RESPONSE ENVELOPE
+// =============================================================================
+// In Java/Spring: a ResponseEntity<ApiResponse<T>> returned by every controller,
+// with @ControllerAdvice turning exceptions into the same shape
+// Go/Gin: One envelope shared by handlers and middleware
+//
+// Success and error bodies carry the same three keys, so clients can always
+// check "error" first and read "data" otherwise:
+//
+//	{"data": {...}, "error": null, "meta": null}
+//	{"data": null, "error": {"message": "user not found"}, "meta": null}
+//
+// It lives in its own package because handler imports middleware, so
+// middleware rejections (401, 415, ...) can't reach handler's helpers.
+// The health endpoints are the exception: probes and Actuator-style tooling
+// expect their bare {"status": "UP"} body.
+
+// APIResponse is the envelope around every API response body
+type APIResponse struct {
+	Data  any       `json:"data"`
+	Error *APIError `json:"error"`
+	Meta  any       `json:"meta"` // e.g. PageMeta for paginated lists; null otherwise
+}
+
+// APIError describes why a request failed
+type APIError struct {
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"` // e.g. schema violations; omitted when nil
+}
+
+// PageMeta is the Meta of a paginated list response
+type PageMeta struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalCount int64 `json:"total_count"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// Error builds the envelope for a failed request
+func Error(message string, details any) APIResponse {
+	return APIResponse{Error: &APIError{Message: message, Details: details}}
+}
+
+// Fail responds with status and err's message in the envelope
+func Fail(c *gin.Context, status int, err error) {
+	c.JSON(status, Error(err.Error(), nil))
+}
+
+// Abort is Fail for middleware: it also stops the rest of the chain
+func Abort(c *gin.Context, status int, message string) {
+	AbortWithDetails(c, status, message, nil)
+}
+
+// AbortWithDetails is Abort with machine-readable details next to the message
+func AbortWithDetails(c *gin.Context, status int, message string, details any) {
+	c.AbortWithStatusJSON(status, Error(message, details))
+}
@@ -0,0 +1,98 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// RETRY WITH EXPONENTIAL BACKOFF
+// =============================================================================
+// In Java/Spring: @Retryable(maxAttempts = 3, backoff = @Backoff(delay = 50, multiplier = 2))
+// Go: A plain function that takes the operation as a closure
+
+// ErrExhausted is returned (wrapping the last error) when all attempts failed
+var ErrExhausted = errors.New("retry attempts exhausted")
+
+// Classifier decides whether an error is worth another attempt
+type Classifier func(err error) bool
+
+// transientMessages are driver messages for errors that usually go away on their own
+// SQLite: locked/busy database, Postgres/MySQL: dropped connections
+var transientMessages = []string{
+	"database is locked",
+	"database table is locked",
+	"sqlite_busy",
+	"connection reset",
+	"broken pipe",
+	"bad connection",
+}
+
+// IsTransient is the default Classifier for DB operations
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range transientMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Do runs fn until it succeeds, returns a non-retryable error, ctx is done,
+// or attempts run out. The wait between attempts starts at backoff and doubles.
+// Without classifiers, only IsTransient errors are retried.
+// Usage:
+//
+//	err := retry.Do(ctx, 3, 50*time.Millisecond, func() error {
+//	    return db.Create(&user).Error
+//	})
+func Do(ctx context.Context, attempts int, backoff time.Duration, fn func() error, retryOn ...Classifier) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if len(retryOn) == 0 {
+		retryOn = []Classifier{IsTransient}
+	}
+
+	var err error
+	delay := backoff
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if !shouldRetry(err, retryOn) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+
+		// Wait for the backoff, but give up early if the caller cancels
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+
+	return fmt.Errorf("%w after %d attempts: %w", ErrExhausted, attempts, err)
+}
+
+func shouldRetry(err error, retryOn []Classifier) bool {
+	for _, isRetryable := range retryOn {
+		if isRetryable(err) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,355 @@
+package seed
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/validation"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// SEED DATA LOADER
+// =============================================================================
+// In Java/Spring: data.sql / Flyway repeatable migrations, or DBUnit datasets in tests
+// Go: A fixture file (JSON or YAML) decoded into plain structs, validated, then inserted
+//
+// Records reference each other by natural key (tag name, student code, course code)
+// rather than by ID, so fixtures don't depend on auto-increment order.
+
+// sampleData is the demo fixture, compiled in so seeding works from any directory
+// Java: a data.sql on the classpath rather than on disk
+//
+//go:embed data.yaml
+var sampleData []byte
+
+// Fixture is the whole seed file
+type Fixture struct {
+	Users       []UserFixture       `json:"users"`
+	Tags        []TagFixture        `json:"tags"`
+	Authors     []AuthorFixture     `json:"authors"`
+	Students    []StudentFixture    `json:"students"`
+	Courses     []CourseFixture     `json:"courses"`
+	Enrollments []EnrollmentFixture `json:"enrollments"`
+	Categories  []CategoryFixture   `json:"categories"`
+}
+
+// UserFixture is a user with their profile and posts
+type UserFixture struct {
+	Name    string         `json:"name"`
+	Email   string         `json:"email"`
+	Age     int            `json:"age"`
+	Profile ProfileFixture `json:"profile"`
+	Posts   []PostFixture  `json:"posts"`
+}
+
+type ProfileFixture struct {
+	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url"`
+	Website   string `json:"website"`
+}
+
+type PostFixture struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+type TagFixture struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// AuthorFixture is an author with their books
+type AuthorFixture struct {
+	Name      string        `json:"name"`
+	Country   string        `json:"country"`
+	BirthDate *time.Time    `json:"birth_date"`
+	Books     []BookFixture `json:"books"`
+}
+
+// BookFixture lists its tags by name; the tags must be declared in Fixture.Tags
+type BookFixture struct {
+	Title string   `json:"title"`
+	ISBN  string   `json:"isbn"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags"`
+}
+
+type StudentFixture struct {
+	Name        string `json:"name"`
+	StudentCode string `json:"student_code"`
+	Email       string `json:"email"`
+}
+
+type CourseFixture struct {
+	Name        string  `json:"name"`
+	Code        string  `json:"code"`
+	Credits     int     `json:"credits"`
+	MaxStudents int     `json:"max_students"`
+	Price       float64 `json:"price"`
+	StartTime   string  `json:"start_time"`
+	EndTime     string  `json:"end_time"`
+	DaysOfWeek  string  `json:"days_of_week"`
+}
+
+// EnrollmentFixture links a student code to a course code
+// A zero EnrolledAt means "now"
+type EnrollmentFixture struct {
+	Student    string    `json:"student"`
+	Course     string    `json:"course"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+	Grade      string    `json:"grade"`
+	Completed  bool      `json:"completed"`
+}
+
+// CategoryFixture is a category tree node
+type CategoryFixture struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Children    []CategoryFixture `json:"children"`
+}
+
+var gradePattern = regexp.MustCompile(`^[ABCDF]$`)
+
+// LoadFromFile reads a .json, .yaml or .yml fixture and inserts it in one transaction
+// Nothing is inserted unless the whole fixture is valid; the error names the failing records
+func LoadFromFile(db *gorm.DB, path string) error {
+	fixture, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return Load(db, fixture)
+}
+
+// Sample returns the bundled demo fixture (seed/data.yaml)
+func Sample() (*Fixture, error) {
+	return Parse(sampleData, "data.yaml")
+}
+
+// ReadFile decodes a fixture file, picking the format from its extension
+func ReadFile(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	return Parse(data, path)
+}
+
+// Parse decodes fixture data; name's extension picks the format
+func Parse(data []byte, name string) (*Fixture, error) {
+	var fixture Fixture
+	var err error
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &fixture)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &fixture)
+	default:
+		return nil, fmt.Errorf("unsupported fixture format %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", name, err)
+	}
+	return &fixture, nil
+}
+
+// Load validates fixture and inserts it in one transaction
+func Load(db *gorm.DB, fixture *Fixture) error {
+	if err := fixture.Validate(); err != nil {
+		return fmt.Errorf("invalid fixture: %w", err)
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fixture.insert(tx)
+	})
+}
+
+// Validate checks every record and reports all problems at once, keyed by record path
+// e.g. "users[1].email: must be a valid email address"
+func (f *Fixture) Validate() error {
+	v := validation.New()
+
+	for i, u := range f.Users {
+		at := fmt.Sprintf("users[%d]", i)
+		v.Check(at+".name", validation.NotEmpty(u.Name)).
+			Check(at+".email", validation.NotEmpty(u.Email), validation.Email(u.Email)).
+			Check(at+".age", validation.Min(u.Age, 0))
+		for j, p := range u.Posts {
+			v.Check(fmt.Sprintf("%s.posts[%d].title", at, j), validation.NotEmpty(p.Title))
+		}
+	}
+
+	tags := make(map[string]bool, len(f.Tags))
+	for i, t := range f.Tags {
+		v.Check(fmt.Sprintf("tags[%d].name", i), validation.NotEmpty(t.Name))
+		tags[t.Name] = true
+	}
+
+	for i, a := range f.Authors {
+		at := fmt.Sprintf("authors[%d]", i)
+		v.Check(at+".name", validation.NotEmpty(a.Name))
+		for j, b := range a.Books {
+			book := fmt.Sprintf("%s.books[%d]", at, j)
+			v.Check(book+".title", validation.NotEmpty(b.Title)).
+				Check(book+".price", validation.Min(b.Price, 0))
+			for k, name := range b.Tags {
+				v.Check(fmt.Sprintf("%s.tags[%d]", book, k), known(tags, name, "tag"))
+			}
+		}
+	}
+
+	students := make(map[string]bool, len(f.Students))
+	for i, s := range f.Students {
+		at := fmt.Sprintf("students[%d]", i)
+		v.Check(at+".name", validation.NotEmpty(s.Name)).
+			Check(at+".student_code", validation.NotEmpty(s.StudentCode))
+		students[s.StudentCode] = true
+	}
+
+	courses := make(map[string]bool, len(f.Courses))
+	for i, c := range f.Courses {
+		at := fmt.Sprintf("courses[%d]", i)
+		v.Check(at+".name", validation.NotEmpty(c.Name)).
+			Check(at+".code", validation.NotEmpty(c.Code)).
+			Check(at+".credits", validation.Min(c.Credits, 0))
+		courses[c.Code] = true
+	}
+
+	for i, e := range f.Enrollments {
+		at := fmt.Sprintf("enrollments[%d]", i)
+		v.Check(at+".student", known(students, e.Student, "student")).
+			Check(at+".course", known(courses, e.Course, "course"))
+		if e.Grade != "" {
+			v.Check(at+".grade", validation.Matches(e.Grade, gradePattern, "must be one of A, B, C, D, F"))
+		}
+	}
+
+	validateCategories(v, "categories", f.Categories)
+	return v.Err()
+}
+
+func validateCategories(v *validation.Validator, path string, categories []CategoryFixture) {
+	for i, c := range categories {
+		at := fmt.Sprintf("%s[%d]", path, i)
+		v.Check(at+".name", validation.NotEmpty(c.Name))
+		validateCategories(v, at+".children", c.Children)
+	}
+}
+
+// known fails when key wasn't declared elsewhere in the fixture
+func known(declared map[string]bool, key, kind string) validation.Rule {
+	return func() string {
+		if !declared[key] {
+			return fmt.Sprintf("unknown %s %q", kind, key)
+		}
+		return ""
+	}
+}
+
+// insert creates every record; errors name the record that failed
+func (f *Fixture) insert(tx *gorm.DB) error {
+	for i, u := range f.Users {
+		user := model.User{
+			Name:  u.Name,
+			Email: u.Email,
+			Age:   u.Age,
+			Profile: model.Profile{
+				Bio:       u.Profile.Bio,
+				AvatarURL: u.Profile.AvatarURL,
+				Website:   u.Profile.Website,
+			},
+		}
+		for _, p := range u.Posts {
+			user.Posts = append(user.Posts, model.Post{Title: p.Title, Content: p.Content})
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("users[%d] (%s): %w", i, u.Email, err)
+		}
+	}
+
+	tags := make(map[string]model.Tag, len(f.Tags))
+	for i, t := range f.Tags {
+		tag := model.Tag{Name: t.Name, Color: t.Color}
+		if err := tx.Create(&tag).Error; err != nil {
+			return fmt.Errorf("tags[%d] (%s): %w", i, t.Name, err)
+		}
+		tags[t.Name] = tag
+	}
+
+	for i, a := range f.Authors {
+		author := model.Author{Name: a.Name, Country: a.Country, BirthDate: a.BirthDate}
+		for _, b := range a.Books {
+			book := model.Book{Title: b.Title, ISBN: b.ISBN, Price: b.Price}
+			for _, name := range b.Tags {
+				book.Tags = append(book.Tags, tags[name])
+			}
+			author.Books = append(author.Books, book)
+		}
+		if err := tx.Create(&author).Error; err != nil {
+			return fmt.Errorf("authors[%d] (%s): %w", i, a.Name, err)
+		}
+	}
+
+	students := make(map[string]uint, len(f.Students))
+	for i, s := range f.Students {
+		student := model.Student{Name: s.Name, StudentCode: s.StudentCode, Email: s.Email}
+		if err := tx.Create(&student).Error; err != nil {
+			return fmt.Errorf("students[%d] (%s): %w", i, s.StudentCode, err)
+		}
+		students[s.StudentCode] = student.ID
+	}
+
+	courses := make(map[string]uint, len(f.Courses))
+	for i, c := range f.Courses {
+		course := model.Course{
+			Name: c.Name, Code: c.Code, Credits: c.Credits, MaxStudents: c.MaxStudents, Price: c.Price,
+			StartTime: c.StartTime, EndTime: c.EndTime, DaysOfWeek: c.DaysOfWeek,
+		}
+		if err := tx.Create(&course).Error; err != nil {
+			return fmt.Errorf("courses[%d] (%s): %w", i, c.Code, err)
+		}
+		courses[c.Code] = course.ID
+	}
+
+	for i, e := range f.Enrollments {
+		enrollment := model.Enrollment{
+			StudentID:  students[e.Student],
+			CourseID:   courses[e.Course],
+			EnrolledAt: e.EnrolledAt,
+			Completed:  e.Completed,
+		}
+		if enrollment.EnrolledAt.IsZero() {
+			enrollment.EnrolledAt = time.Now()
+		}
+		if e.Grade != "" {
+			grade := e.Grade
+			enrollment.Grade = &grade
+		}
+		if err := tx.Create(&enrollment).Error; err != nil {
+			return fmt.Errorf("enrollments[%d] (%s in %s): %w", i, e.Student, e.Course, err)
+		}
+	}
+
+	return insertCategories(tx, "categories", f.Categories, nil)
+}
+
+func insertCategories(tx *gorm.DB, path string, categories []CategoryFixture, parentID *uint) error {
+	for i, c := range categories {
+		at := fmt.Sprintf("%s[%d]", path, i)
+		category := model.Category{Name: c.Name, Description: c.Description, ParentID: parentID}
+		if err := tx.Create(&category).Error; err != nil {
+			return fmt.Errorf("%s (%s): %w", at, c.Name, err)
+		}
+		if err := insertCategories(tx, at+".children", c.Children, &category.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/validation"
+)
+
+// =============================================================================
+// BOOK SERVICE - Business Logic Layer
+// =============================================================================
+// In Java/Spring: @Service class wrapping BookRepository
+
+// BookService defines business operations for books
+type BookService interface {
+	// SetTags replaces the book's tags with names, creating new tags as needed
+	SetTags(bookID uint, names []string) ([]model.Tag, error)
+
+	// RelatedBooks lists books sharing the most tags with bookID
+	RelatedBooks(bookID uint, limit int) ([]model.RelatedBook, error)
+}
+
+type bookService struct {
+	repo repository.BookRepository
+}
+
+// NewBookService creates a BookService with injected repository
+func NewBookService(repo repository.BookRepository) BookService {
+	return &bookService{repo: repo}
+}
+
+// maxTagNameLength matches the tags.name column (size:50)
+const maxTagNameLength = 50
+
+// SetTags trims and de-duplicates names before replacing the tag set
+// An empty list removes every tag from the book
+func (s *bookService) SetTags(bookID uint, names []string) ([]model.Tag, error) {
+	v := validation.New()
+	seen := make(map[string]bool, len(names))
+	unique := make([]string, 0, len(names))
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		field := fmt.Sprintf("tags[%d]", i)
+		v.Check(field, validation.NotEmpty(name), maxLength(name, maxTagNameLength))
+		if name != "" && !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReplaceTagsByName(bookID, unique)
+}
+
+// RelatedBooks defaults limit to 10 when unset and caps it at 50
+func (s *bookService) RelatedBooks(bookID uint, limit int) ([]model.RelatedBook, error) {
+	switch {
+	case limit < 1:
+		limit = 10
+	case limit > 50:
+		limit = 50
+	}
+	return s.repo.FindRelated(bookID, limit)
+}
+
+// maxLength fails for strings longer than n characters
+func maxLength(s string, n int) validation.Rule {
+	return func() string {
+		if len([]rune(s)) > n {
+			return fmt.Sprintf("must be at most %d characters", n)
+		}
+		return ""
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+)
+
+// =============================================================================
+// ENROLLMENT SERVICE - Business rules on top of EnrollmentRepository
+// =============================================================================
+// In Java/Spring: @Service with a validation step before repository.save()
+// Go: Check the course exists, then delegate to the repository, which also
+// rejects timetable clashes (see repository/schedule.go) so waitlist promotion can't skip them
+
+// EnrollmentService defines business operations for enrollments
+type EnrollmentService interface {
+	// Enroll signs the student up unless the course clashes with their timetable
+	// (a *repository.ScheduleConflictError)
+	Enroll(studentID, courseID uint) (*model.Enrollment, error)
+}
+
+type enrollmentService struct {
+	repo repository.EnrollmentRepository
+}
+
+// NewEnrollmentService creates an EnrollmentService with injected repository
+func NewEnrollmentService(repo repository.EnrollmentRepository) EnrollmentService {
+	return &enrollmentService{repo: repo}
+}
+
+// Enroll rejects unknown courses and courses overlapping the student's current enrollments
+func (s *enrollmentService) Enroll(studentID, courseID uint) (*model.Enrollment, error) {
+	course, err := s.repo.FindCourse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find course: %w", err)
+	}
+	if course == nil {
+		return nil, errors.New("course not found")
+	}
+	return s.repo.Enroll(studentID, courseID)
+}
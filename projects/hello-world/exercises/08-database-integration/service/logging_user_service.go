@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/querybuilder"
+	"go.uber.org/zap"
+)
+
+// =============================================================================
+// LOGGING DECORATOR - Slow call detection
+// =============================================================================
+// In Java/Spring: an @Around aspect on @Service beans timing each call
+// Go: A struct that implements UserService by wrapping another UserService
+
+// LoggingUserService times every UserService call and warns about slow ones
+// Handlers can't tell it apart from the service it wraps
+type LoggingUserService struct {
+	next      UserService
+	log       *zap.Logger
+	threshold time.Duration
+}
+
+// NewLoggingUserService wraps next, logging calls that take threshold or longer
+func NewLoggingUserService(next UserService, log *zap.Logger, threshold time.Duration) *LoggingUserService {
+	return &LoggingUserService{next: next, log: log, threshold: threshold}
+}
+
+// observe logs method if it has run for at least the threshold
+// Usage: defer s.observe("Register", time.Now())
+func (s *LoggingUserService) observe(method string, start time.Time) {
+	elapsed := time.Since(start)
+	if elapsed < s.threshold {
+		return
+	}
+	s.log.Warn("slow service call",
+		zap.String("method", "UserService."+method),
+		zap.Duration("duration", elapsed),
+		zap.Duration("threshold", s.threshold),
+	)
+}
+
+func (s *LoggingUserService) Register(name, email string, age int) (*model.User, error) {
+	defer s.observe("Register", time.Now())
+	return s.next.Register(name, email, age)
+}
+
+func (s *LoggingUserService) GetByID(id uint) (*model.User, error) {
+	defer s.observe("GetByID", time.Now())
+	return s.next.GetByID(id)
+}
+
+func (s *LoggingUserService) GetByIDWithProfile(id uint) (*model.User, error) {
+	defer s.observe("GetByIDWithProfile", time.Now())
+	return s.next.GetByIDWithProfile(id)
+}
+
+func (s *LoggingUserService) GetByIDs(ids []uint) ([]model.User, error) {
+	defer s.observe("GetByIDs", time.Now())
+	return s.next.GetByIDs(ids)
+}
+
+func (s *LoggingUserService) GetByEmail(email string) (*model.User, error) {
+	defer s.observe("GetByEmail", time.Now())
+	return s.next.GetByEmail(email)
+}
+
+func (s *LoggingUserService) GetAll(ctx context.Context) ([]model.User, error) {
+	defer s.observe("GetAll", time.Now())
+	return s.next.GetAll(ctx)
+}
+
+func (s *LoggingUserService) GetAllPaginated(page, pageSize int) ([]model.User, int64, error) {
+	defer s.observe("GetAllPaginated", time.Now())
+	return s.next.GetAllPaginated(page, pageSize)
+}
+
+// PageSize is pure arithmetic, so it isn't timed
+func (s *LoggingUserService) PageSize(requested int) int {
+	return s.next.PageSize(requested)
+}
+
+func (s *LoggingUserService) ListUsers(ctx context.Context, q *querybuilder.Query) ([]model.User, int64, error) {
+	defer s.observe("ListUsers", time.Now())
+	return s.next.ListUsers(ctx, q)
+}
+
+func (s *LoggingUserService) UpdateProfile(userID uint, bio, avatarURL, website string) error {
+	defer s.observe("UpdateProfile", time.Now())
+	return s.next.UpdateProfile(userID, bio, avatarURL, website)
+}
+
+func (s *LoggingUserService) Delete(id uint) error {
+	defer s.observe("Delete", time.Now())
+	return s.next.Delete(id)
+}
+
+func (s *LoggingUserService) SearchUsers(ctx context.Context, query string) ([]model.User, error) {
+	defer s.observe("SearchUsers", time.Now())
+	return s.next.SearchUsers(ctx, query)
+}
+
+func (s *LoggingUserService) GetAdults() ([]model.User, error) {
+	defer s.observe("GetAdults", time.Now())
+	return s.next.GetAdults()
+}
+
+func (s *LoggingUserService) GetPostStats(userID uint) (*model.PostStats, error) {
+	defer s.observe("GetPostStats", time.Now())
+	return s.next.GetPostStats(userID)
+}
+
+func (s *LoggingUserService) BulkDelete(ids []uint) (int64, []uint, error) {
+	defer s.observe("BulkDelete", time.Now())
+	return s.next.BulkDelete(ids)
+}
+
+func (s *LoggingUserService) ChangeRole(id uint, role string) (*model.User, error) {
+	defer s.observe("ChangeRole", time.Now())
+	return s.next.ChangeRole(id, role)
+}
+
+func (s *LoggingUserService) HardDelete(id uint) error {
+	defer s.observe("HardDelete", time.Now())
+	return s.next.HardDelete(id)
+}
+
+func (s *LoggingUserService) PurgeDeleted(olderThan time.Duration) (int64, error) {
+	defer s.observe("PurgeDeleted", time.Now())
+	return s.next.PurgeDeleted(olderThan)
+}
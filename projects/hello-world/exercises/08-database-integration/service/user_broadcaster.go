@@ -0,0 +1,63 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+)
+
+// =============================================================================
+// USER EVENTS - In-process pub/sub
+// =============================================================================
+// In Java/Spring: ApplicationEventPublisher + @EventListener
+// Go: A set of channels guarded by a mutex
+
+// UserBroadcaster fans newly registered users out to every subscriber
+type UserBroadcaster struct {
+	mu   sync.Mutex
+	subs map[chan model.User]struct{}
+}
+
+// NewUserBroadcaster creates an empty broadcaster
+func NewUserBroadcaster() *UserBroadcaster {
+	return &UserBroadcaster{subs: make(map[chan model.User]struct{})}
+}
+
+// Subscribe registers a listener; call the returned func to unsubscribe
+func (b *UserBroadcaster) Subscribe() (<-chan model.User, func()) {
+	ch := make(chan model.User, 16)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+		})
+	}
+}
+
+// Publish sends user to every subscriber without blocking
+// A subscriber whose buffer is full misses the event instead of stalling Register
+func (b *UserBroadcaster) Publish(user model.User) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- user:
+		default:
+		}
+	}
+}
+
+// Subscribers returns the number of active listeners
+func (b *UserBroadcaster) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
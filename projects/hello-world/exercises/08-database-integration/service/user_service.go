@@ -1,11 +1,17 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/config"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/querybuilder"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/validation"
 )
 
 // =============================================================================
@@ -19,26 +25,73 @@ type UserService interface {
 	Register(name, email string, age int) (*model.User, error)
 	GetByID(id uint) (*model.User, error)
 	GetByIDWithProfile(id uint) (*model.User, error)
+	GetByIDs(ids []uint) ([]model.User, error) // Batch lookup; unknown ids are omitted
 	GetByEmail(email string) (*model.User, error)
-	GetAll() ([]model.User, error)
+	GetAll(ctx context.Context) ([]model.User, error) // ctx cancels the query, e.g. when the client disconnects
 	GetAllPaginated(page, pageSize int) ([]model.User, int64, error)
+	PageSize(requested int) int // The page size GetAllPaginated will actually use
+	ListUsers(ctx context.Context, q *querybuilder.Query) ([]model.User, int64, error)
 	UpdateProfile(userID uint, bio, avatarURL, website string) error
 	Delete(id uint) error
 
 	// Business operations
-	SearchUsers(query string) ([]model.User, error)
+	SearchUsers(ctx context.Context, query string) ([]model.User, error)
 	GetAdults() ([]model.User, error)
+	GetPostStats(userID uint) (*model.PostStats, error)
+
+	// Admin operations
+	BulkDelete(ids []uint) (deleted int64, notFound []uint, err error)
+	ChangeRole(id uint, role string) (*model.User, error) // role must be one of model.Roles
+	HardDelete(id uint) error
+	PurgeDeleted(olderThan time.Duration) (int64, error)
 }
 
+// ErrConflict is returned when a write collides with an existing row, e.g. a taken email
+// Java equivalent: DataIntegrityViolationException mapped to 409 Conflict
+var ErrConflict = errors.New("conflict")
+
 // userService implements UserService
 type userService struct {
-	repo repository.UserRepository
+	repo       repository.UserRepository
+	events     *UserBroadcaster // optional: notified on Register
+	onWrite    func()           // optional: called after any successful write
+	pagination config.PaginationConfig
+}
+
+// Option configures optional userService dependencies
+type Option func(*userService)
+
+// WithEvents publishes registered users to events
+// Java equivalent: injecting ApplicationEventPublisher
+func WithEvents(events *UserBroadcaster) Option {
+	return func(s *userService) { s.events = events }
+}
+
+// WithCacheInvalidation calls invalidate after every successful write
+// Java equivalent: @CacheEvict on the write methods
+func WithCacheInvalidation(invalidate func()) Option {
+	return func(s *userService) { s.onWrite = invalidate }
+}
+
+// WithPagination replaces the default page size limits (10 per page, at most 100)
+// Java equivalent: spring.data.web.pageable.* properties
+func WithPagination(pagination config.PaginationConfig) Option {
+	return func(s *userService) { s.pagination = pagination }
 }
 
 // NewUserService creates a UserService with injected dependencies
 // Java equivalent: @Service class with @Autowired constructor
-func NewUserService(repo repository.UserRepository) UserService {
-	return &userService{repo: repo}
+func NewUserService(repo repository.UserRepository, opts ...Option) UserService {
+	s := &userService{repo: repo, pagination: config.DefaultPagination()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewUserServiceWithEvents is NewUserService that also publishes registered users
+func NewUserServiceWithEvents(repo repository.UserRepository, events *UserBroadcaster) UserService {
+	return NewUserService(repo, WithEvents(events))
 }
 
 // =============================================================================
@@ -49,14 +102,14 @@ func NewUserService(repo repository.UserRepository) UserService {
 // Java: @Transactional public User register(RegisterRequest request) { ... }
 func (s *userService) Register(name, email string, age int) (*model.User, error) {
 	// Business validation (not just database constraints)
-	if name == "" {
-		return nil, errors.New("name is required")
-	}
-	if email == "" {
-		return nil, errors.New("email is required")
-	}
-	if age < 0 {
-		return nil, errors.New("age cannot be negative")
+	// Java: @Valid on the request with @NotBlank/@Email/@Min
+	err := validation.New().
+		Check("name", validation.NotEmpty(name)).
+		Check("email", validation.NotEmpty(email), validation.Email(email)).
+		Check("age", validation.Min(age, 0)).
+		Err()
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if email already exists (business rule)
@@ -65,7 +118,7 @@ func (s *userService) Register(name, email string, age int) (*model.User, error)
 		return nil, fmt.Errorf("failed to check email: %w", err)
 	}
 	if exists {
-		return nil, errors.New("email already registered")
+		return nil, fmt.Errorf("%w: email already registered", ErrConflict)
 	}
 
 	// Create user with default profile
@@ -79,9 +132,19 @@ func (s *userService) Register(name, email string, age int) (*model.User, error)
 	}
 
 	if err := s.repo.Create(user); err != nil {
+		// The check above can race a concurrent Register, and it skips soft-deleted
+		// users, whose rows still hold the email's unique index
+		if database.IsUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: email already registered", ErrConflict)
+		}
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	s.written()
+	if s.events != nil {
+		s.events.Publish(*user)
+	}
+
 	return user, nil
 }
 
@@ -97,6 +160,15 @@ func (s *userService) GetByID(id uint) (*model.User, error) {
 	return user, nil
 }
 
+// GetByIDs retrieves several users in one query
+func (s *userService) GetByIDs(ids []uint) ([]model.User, error) {
+	users, err := s.repo.FindByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+	return users, nil
+}
+
 // GetByIDWithProfile retrieves user with profile loaded
 func (s *userService) GetByIDWithProfile(id uint) (*model.User, error) {
 	user, err := s.repo.FindByIDWithProfile(id)
@@ -118,8 +190,8 @@ func (s *userService) GetByEmail(email string) (*model.User, error) {
 }
 
 // GetAll retrieves all users
-func (s *userService) GetAll() ([]model.User, error) {
-	return s.repo.FindAll()
+func (s *userService) GetAll(ctx context.Context) ([]model.User, error) {
+	return s.repo.FindAll(ctx)
 }
 
 // GetAllPaginated retrieves users with pagination
@@ -127,29 +199,33 @@ func (s *userService) GetAllPaginated(page, pageSize int) ([]model.User, int64,
 	if page < 1 {
 		page = 1
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
-	}
-	return s.repo.FindAllWithPagination(page, pageSize)
+	return s.repo.FindAllWithPagination(page, s.PageSize(pageSize))
+}
+
+// ListUsers returns the page of users described by q
+// q.PageSize is replaced by the size actually used (see PageSize)
+func (s *userService) ListUsers(ctx context.Context, q *querybuilder.Query) ([]model.User, int64, error) {
+	q.PageSize = s.PageSize(q.PageSize)
+	return s.repo.FindByQuery(ctx, q)
+}
+
+// PageSize applies the configured default and cap to a requested page size
+func (s *userService) PageSize(requested int) int {
+	return s.pagination.PageSize(requested)
 }
 
 // UpdateProfile updates a user's profile
 // Demonstrates working with One-to-One relationship
 func (s *userService) UpdateProfile(userID uint, bio, avatarURL, website string) error {
-	user, err := s.repo.FindByIDWithProfile(userID)
-	if err != nil {
-		return fmt.Errorf("failed to find user: %w", err)
+	// Upsert rather than Save(user): users created before profiles existed have none
+	if _, err := s.repo.UpsertProfile(userID, bio, avatarURL, website); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return errors.New("user not found")
+		}
+		return fmt.Errorf("failed to update profile: %w", err)
 	}
-	if user == nil {
-		return errors.New("user not found")
-	}
-
-	// Update profile fields
-	user.Profile.Bio = bio
-	user.Profile.AvatarURL = avatarURL
-	user.Profile.Website = website
-
-	return s.repo.Update(user)
+	s.written()
+	return nil
 }
 
 // Delete removes a user (soft delete)
@@ -160,18 +236,29 @@ func (s *userService) Delete(id uint) error {
 		return fmt.Errorf("failed to find user: %w", err)
 	}
 	if user == nil {
-		return errors.New("user not found")
+		return repository.ErrUserNotFound
 	}
 
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	s.written()
+	return nil
+}
+
+// written runs the write hook so cached reads don't serve stale data
+func (s *userService) written() {
+	if s.onWrite != nil {
+		s.onWrite()
+	}
 }
 
 // SearchUsers searches users by name
-func (s *userService) SearchUsers(query string) ([]model.User, error) {
+func (s *userService) SearchUsers(ctx context.Context, query string) ([]model.User, error) {
 	if query == "" {
-		return s.repo.FindAll()
+		return s.repo.FindAll(ctx)
 	}
-	return s.repo.FindByNameContaining(query)
+	return s.repo.FindByNameContaining(ctx, query)
 }
 
 // GetAdults retrieves all users 18 and older
@@ -179,3 +266,95 @@ func (s *userService) GetAdults() ([]model.User, error) {
 	return s.repo.FindByAgeGreaterThan(17)
 }
 
+// GetPostStats returns post count and last post date for an existing user
+func (s *userService) GetPostStats(userID uint) (*model.PostStats, error) {
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	stats, err := s.repo.PostStats(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load post stats: %w", err)
+	}
+	return stats, nil
+}
+
+// BulkDelete soft-deletes the given users together, reporting the ids that weren't found
+// Duplicate ids count once
+func (s *userService) BulkDelete(ids []uint) (int64, []uint, error) {
+	deleted, err := s.repo.DeleteMany(ids)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to delete users: %w", err)
+	}
+
+	found := make(map[uint]bool, len(deleted))
+	for _, id := range deleted {
+		found[id] = true
+	}
+	notFound := []uint{}
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if !found[id] && !seen[id] {
+			notFound = append(notFound, id)
+		}
+		seen[id] = true
+	}
+
+	if len(deleted) > 0 {
+		s.written()
+	}
+	return int64(len(deleted)), notFound, nil
+}
+
+// HardDelete permanently removes a user, including soft-deleted ones
+func (s *userService) HardDelete(id uint) error {
+	if err := s.repo.HardDelete(id); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	s.written()
+	return nil
+}
+
+// ChangeRole sets a user's role and returns the updated user
+// Java: @PreAuthorize("hasRole('ADMIN')") public User changeRole(Long id, Role role)
+func (s *userService) ChangeRole(id uint, role string) (*model.User, error) {
+	err := validation.New().
+		Check("role", validation.OneOf(role, model.Roles...)).
+		Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRole(id, role); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to change role: %w", err)
+	}
+	s.written()
+
+	return s.GetByID(id)
+}
+
+// PurgeDeleted permanently removes users soft-deleted more than olderThan ago
+// Java: the body of a @Scheduled retention job
+func (s *userService) PurgeDeleted(olderThan time.Duration) (int64, error) {
+	if olderThan < 0 {
+		return 0, errors.New("retention period cannot be negative")
+	}
+	purged, err := s.repo.PurgeDeletedBefore(time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge users: %w", err)
+	}
+	if purged > 0 {
+		s.written()
+	}
+	return purged, nil
+}
@@ -0,0 +1,333 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"gorm.io/gorm"
+)
+
+const (
+	adminToken = "admin-token"
+	userToken  = "user-token"
+)
+
+func newAdminRouter(db *gorm.DB) *gin.Engine {
+	tokens := map[string]middleware.Principal{
+		adminToken: {UserID: 1, Role: "admin"},
+		userToken:  {UserID: 2, Role: "user"},
+	}
+	r := gin.New()
+	svc := service.NewUserService(repository.NewUserRepository(db))
+	handler.NewAdminHandler(svc, repository.NewStatsRepository(db)).RegisterRoutes(r, middleware.TokenAuth(tokens))
+	return r
+}
+
+// softDeleteAt marks user as deleted at the given time
+func softDeleteAt(t *testing.T, db *gorm.DB, user *model.User, at time.Time) {
+	t.Helper()
+	if err := db.Model(user).Update("deleted_at", at).Error; err != nil {
+		t.Fatalf("Failed to soft delete user: %v", err)
+	}
+}
+
+func adminRequest(r *gin.Engine, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func userExists(t *testing.T, db *gorm.DB, id uint) bool {
+	t.Helper()
+	var count int64
+	if err := db.Unscoped().Model(&model.User{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count users: %v", err)
+	}
+	return count > 0
+}
+
+func TestPurgeRespectsAgeThreshold(t *testing.T) {
+	db := newTestDB(t)
+	longGone := seedUser(t, db, "old@example.com")
+	recentlyGone := seedUser(t, db, "recent@example.com")
+	active := seedUser(t, db, "active@example.com")
+	softDeleteAt(t, db, longGone, time.Now().Add(-40*24*time.Hour))
+	softDeleteAt(t, db, recentlyGone, time.Now().Add(-5*24*time.Hour))
+	if err := db.Create(&model.Post{Title: "orphan?", UserID: longGone.ID}).Error; err != nil {
+		t.Fatalf("Failed to seed post: %v", err)
+	}
+
+	w := adminRequest(newAdminRouter(db), http.MethodPost, "/api/admin/users/purge", adminToken, `{"older_than_days":30}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp handler.PurgeResponse
+	if err := decodeData(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Purged != 1 {
+		t.Errorf("Expected 1 purged user, got %d", resp.Purged)
+	}
+
+	tests := []struct {
+		name string
+		id   uint
+		want bool
+	}{
+		{"deleted 40 days ago is purged", longGone.ID, false},
+		{"deleted 5 days ago is kept", recentlyGone.ID, true},
+		{"active user is kept", active.ID, true},
+	}
+	for _, tt := range tests {
+		if got := userExists(t, db, tt.id); got != tt.want {
+			t.Errorf("%s: Expected exists=%v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	var posts int64
+	db.Unscoped().Model(&model.Post{}).Where("user_id = ?", longGone.ID).Count(&posts)
+	if posts != 0 {
+		t.Errorf("Expected purged user's posts to be removed, got %d", posts)
+	}
+}
+
+func TestAdminDeleteWithPurge(t *testing.T) {
+	db := newTestDB(t)
+	user := seedUser(t, db, "purge-me@example.com")
+	r := newAdminRouter(db)
+
+	w := adminRequest(r, http.MethodDelete, "/api/admin/users/"+itoa(user.ID)+"?purge=true", adminToken, "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if userExists(t, db, user.ID) {
+		t.Error("Expected user row to be gone after purge")
+	}
+
+	w = adminRequest(r, http.MethodDelete, "/api/admin/users/"+itoa(user.ID)+"?purge=true", adminToken, "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for already purged user, got %d", w.Code)
+	}
+}
+
+func TestAdminDeleteStatusCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		closeDB    bool
+		wantStatus int
+	}{
+		{"soft delete of missing user", "", false, http.StatusNotFound},
+		{"purge of missing user", "?purge=true", false, http.StatusNotFound},
+		{"soft delete with database down", "", true, http.StatusInternalServerError},
+		{"purge with database down", "?purge=true", true, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		db := newTestDB(t)
+		r := newAdminRouter(db)
+		if tt.closeDB {
+			sqlDB, _ := db.DB()
+			sqlDB.Close()
+		}
+
+		w := adminRequest(r, http.MethodDelete, "/api/admin/users/9999"+tt.query, adminToken, "")
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: Expected %d, got %d: %s", tt.name, tt.wantStatus, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestAdminRoutesRequireAdminRole(t *testing.T) {
+	db := newTestDB(t)
+	user := seedUser(t, db, "safe@example.com")
+	r := newAdminRouter(db)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		body       string
+		wantStatus int
+	}{
+		{"purge without token", http.MethodPost, "/api/admin/users/purge", "", `{"older_than_days":1}`, http.StatusUnauthorized},
+		{"purge as regular user", http.MethodPost, "/api/admin/users/purge", userToken, `{"older_than_days":1}`, http.StatusForbidden},
+		{"hard delete as regular user", http.MethodDelete, "/api/admin/users/" + itoa(user.ID) + "?purge=true", userToken, "", http.StatusForbidden},
+		{"unknown token", http.MethodDelete, "/api/admin/users/" + itoa(user.ID), "bogus", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := adminRequest(r, tt.method, tt.path, tt.token, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+	if !userExists(t, db, user.ID) {
+		t.Error("Expected user to survive rejected requests")
+	}
+}
+
+func TestAdminStatsCountsLiveAndDeletedRows(t *testing.T) {
+	db := newTestDB(t)
+
+	alice := seedUser(t, db, "alice@example.com")
+	seedUser(t, db, "bob@example.com")
+	gone := seedUser(t, db, "gone@example.com")
+	db.Delete(&gone)
+	for _, title := range []string{"First", "Second", "Third"} {
+		db.Create(&model.Post{Title: title, UserID: alice.ID})
+	}
+	books := seedTaggedBooks(t, db, 4, model.Tag{Name: "stats"})
+	db.Delete(&books[0])
+	student := seedStudent(t, db, "STU001")
+	course := seedCourse(t, db, "CS101", 3)
+	seedCourse(t, db, "CS102", 3)
+	db.Create(&model.Enrollment{StudentID: student.ID, CourseID: course.ID, EnrolledAt: time.Now()})
+
+	w := adminRequest(newAdminRouter(db), http.MethodGet, "/api/admin/stats", adminToken, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var stats model.DatabaseStats
+	if err := decodeData(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	want := model.DatabaseStats{
+		Users:       model.TableCount{Live: 2, Deleted: 1},
+		Books:       model.TableCount{Live: 3, Deleted: 1},
+		Courses:     model.TableCount{Live: 2},
+		Enrollments: model.TableCount{Live: 1},
+		Posts:       model.TableCount{Live: 3},
+	}
+	if stats != want {
+		t.Errorf("Expected %+v, got %+v", want, stats)
+	}
+
+	if w := adminRequest(newAdminRouter(db), http.MethodGet, "/api/admin/stats", userToken, ""); w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a regular user, got %d", w.Code)
+	}
+}
+
+func itoa(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func TestBulkDeleteReportsMissingIDs(t *testing.T) {
+	db := newTestDB(t)
+	a := seedUser(t, db, "a@example.com")
+	b := seedUser(t, db, "b@example.com")
+	keep := seedUser(t, db, "keep@example.com")
+	gone := seedUser(t, db, "gone@example.com")
+	db.Delete(gone)
+
+	body := `{"ids":[` + itoa(a.ID) + `,` + itoa(b.ID) + `,` + itoa(b.ID) + `,` + itoa(gone.ID) + `,9999]}`
+	w := adminRequest(newAdminRouter(db), http.MethodPost, "/api/admin/users/bulk-delete", adminToken, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp handler.BulkDeleteResponse
+	if err := decodeData(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.Deleted != 2 {
+		t.Errorf("Expected 2 deleted, got %d", resp.Deleted)
+	}
+	wantMissing := []uint{gone.ID, 9999} // Already soft-deleted counts as not found
+	if fmt.Sprint(resp.NotFound) != fmt.Sprint(wantMissing) {
+		t.Errorf("Expected not_found %v, got %v", wantMissing, resp.NotFound)
+	}
+	var live []uint
+	db.Model(&model.User{}).Order("id").Pluck("id", &live)
+	if fmt.Sprint(live) != fmt.Sprint([]uint{keep.ID}) {
+		t.Errorf("Expected only user %d left, got %v", keep.ID, live)
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		body       string
+		wantStatus int
+	}{
+		{"empty list", adminToken, `{"ids":[]}`, http.StatusBadRequest},
+		{"missing ids", adminToken, `{}`, http.StatusBadRequest},
+		{"regular user", userToken, `{"ids":[` + itoa(keep.ID) + `]}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := adminRequest(newAdminRouter(db), http.MethodPost, "/api/admin/users/bulk-delete", tt.token, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestChangeRole(t *testing.T) {
+	db := newTestDB(t)
+	user := seedUser(t, db, "promote@example.com")
+	if user.Role != model.RoleUser {
+		t.Fatalf("Expected new users to default to role %s, got %q", model.RoleUser, user.Role)
+	}
+	r := newAdminRouter(db)
+	path := "/api/admin/users/" + itoa(user.ID) + "/role"
+
+	tests := []struct {
+		name       string
+		token      string
+		path       string
+		body       string
+		wantStatus int
+		wantRole   string
+	}{
+		{"non-admin rejected", userToken, path, `{"role":"admin"}`, http.StatusForbidden, model.RoleUser},
+		{"no token", "", path, `{"role":"admin"}`, http.StatusUnauthorized, model.RoleUser},
+		{"unknown role", adminToken, path, `{"role":"superuser"}`, http.StatusBadRequest, model.RoleUser},
+		{"missing role", adminToken, path, `{}`, http.StatusBadRequest, model.RoleUser},
+		{"unknown user", adminToken, "/api/admin/users/9999/role", `{"role":"admin"}`, http.StatusNotFound, model.RoleUser},
+		{"admin promotes", adminToken, path, `{"role":"admin"}`, http.StatusOK, model.RoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := adminRequest(r, http.MethodPut, tt.path, tt.token, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			var stored model.User
+			db.First(&stored, user.ID)
+			if stored.Role != tt.wantRole {
+				t.Errorf("Expected stored role %s, got %s", tt.wantRole, stored.Role)
+			}
+
+			if tt.wantStatus == http.StatusOK {
+				var resp model.User
+				if err := decodeData(w.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if resp.Role != tt.wantRole {
+					t.Errorf("Expected role %s in response, got %s", tt.wantRole, resp.Role)
+				}
+			}
+		})
+	}
+}
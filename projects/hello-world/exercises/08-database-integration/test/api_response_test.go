@@ -0,0 +1,174 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/response"
+)
+
+// decodeData unmarshals the data field of an APIResponse body into v
+func decodeData(body []byte, v interface{}) error {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return err
+	}
+	return json.Unmarshal(envelope.Data, v)
+}
+
+// envelopeKeys returns the sorted top-level keys of a JSON object body
+func envelopeKeys(t *testing.T, body []byte) []string {
+	t.Helper()
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatalf("Expected a JSON object, got %s", body)
+	}
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestResponsesShareEnvelopeShape(t *testing.T) {
+	db := newTestDB(t)
+	user := seedUser(t, db, "envelope@example.com")
+	r := newUserRouter(db)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantError  bool
+		wantMeta   bool
+	}{
+		{"single resource", "/api/users/" + itoa(user.ID), http.StatusOK, false, false},
+		{"list", "/api/users", http.StatusOK, false, false},
+		{"paginated list", "/api/users?page=1&page_size=5", http.StatusOK, false, true},
+		{"not found", "/api/users/9999", http.StatusNotFound, true, false},
+		{"bad id", "/api/users/abc", http.StatusBadRequest, true, false},
+	}
+
+	wantKeys := []string{"data", "error", "meta"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			if keys := envelopeKeys(t, w.Body.Bytes()); !reflect.DeepEqual(keys, wantKeys) {
+				t.Errorf("Expected keys %v, got %v", wantKeys, keys)
+			}
+
+			var resp struct {
+				Data  json.RawMessage    `json:"data"`
+				Error *response.APIError `json:"error"`
+				Meta  *response.PageMeta `json:"meta"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if tt.wantError {
+				if resp.Error == nil || resp.Error.Message == "" {
+					t.Errorf("Expected an error message, got %s", w.Body.String())
+				}
+				if string(resp.Data) != "null" {
+					t.Errorf("Expected null data on error, got %s", resp.Data)
+				}
+			} else if resp.Error != nil {
+				t.Errorf("Expected null error on success, got %+v", resp.Error)
+			}
+			if tt.wantMeta != (resp.Meta != nil) {
+				t.Errorf("Expected meta present=%v, got %s", tt.wantMeta, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPaginatedResponseMeta(t *testing.T) {
+	db := newTestDB(t)
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		seedUser(t, db, email)
+	}
+
+	w := httptest.NewRecorder()
+	newUserRouter(db).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users?page=2&page_size=2", nil))
+
+	var resp struct {
+		Data []map[string]interface{} `json:"data"`
+		Meta response.PageMeta        `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	want := response.PageMeta{Page: 2, PageSize: 2, TotalCount: 3, TotalPages: 2}
+	if resp.Meta != want {
+		t.Errorf("Expected meta %+v, got %+v", want, resp.Meta)
+	}
+	if len(resp.Data) != 1 {
+		t.Errorf("Expected 1 user on the last page, got %d", len(resp.Data))
+	}
+}
+
+func TestMiddlewareRejectionsUseEnvelope(t *testing.T) {
+	tokens := map[string]middleware.Principal{"user-token": {Role: "user"}}
+	r := gin.New()
+	r.Use(middleware.APIVersion("1"), middleware.RequireJSON())
+	r.POST("/things", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/admin", middleware.TokenAuth(tokens), middleware.RequireRole("admin"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		header      map[string]string
+		wantStatus  int
+		wantDetails bool
+	}{
+		{"wrong content type", http.MethodPost, "/things", map[string]string{"Content-Type": "text/plain"}, http.StatusUnsupportedMediaType, false},
+		{"unknown version", http.MethodGet, "/admin", map[string]string{middleware.AcceptVersionHeader: "9"}, http.StatusBadRequest, true},
+		{"no token", http.MethodGet, "/admin", nil, http.StatusUnauthorized, false},
+		{"wrong role", http.MethodGet, "/admin", map[string]string{"Authorization": "Bearer user-token"}, http.StatusForbidden, false},
+	}
+
+	wantKeys := []string{"data", "error", "meta"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("x"))
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			if keys := envelopeKeys(t, w.Body.Bytes()); !reflect.DeepEqual(keys, wantKeys) {
+				t.Errorf("Expected keys %v, got %v", wantKeys, keys)
+			}
+			var resp response.APIResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if resp.Error == nil || resp.Error.Message == "" {
+				t.Fatalf("Expected an error message, got %s", w.Body.String())
+			}
+			if tt.wantDetails != (resp.Error.Details != nil) {
+				t.Errorf("Expected details present=%v, got %s", tt.wantDetails, w.Body.String())
+			}
+		})
+	}
+}
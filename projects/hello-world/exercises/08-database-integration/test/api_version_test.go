@@ -0,0 +1,54 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+)
+
+func TestAPIVersion(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.APIVersion("1", "2"))
+	r.GET("/things", func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.GetAPIVersion(c))
+	})
+
+	tests := []struct {
+		name        string
+		header      string
+		wantStatus  int
+		wantVersion string
+	}{
+		{"supported older version", "1", http.StatusOK, "1"},
+		{"supported latest version", "2", http.StatusOK, "2"},
+		{"absent defaults to latest", "", http.StatusOK, "2"},
+		{"unsupported version", "3", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/things", nil)
+			if tt.header != "" {
+				req.Header.Set(middleware.AcceptVersionHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := w.Body.String(); got != tt.wantVersion {
+				t.Errorf("Expected handler to see version %s, got %s", tt.wantVersion, got)
+			}
+			if got := w.Header().Get(middleware.ContentVersionHeader); got != tt.wantVersion {
+				t.Errorf("Expected Content-Version %s, got %s", tt.wantVersion, got)
+			}
+		})
+	}
+}
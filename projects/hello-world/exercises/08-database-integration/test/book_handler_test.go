@@ -0,0 +1,253 @@
+package test
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"gorm.io/gorm"
+)
+
+func newBookRouter(db *gorm.DB) *gin.Engine {
+	r := gin.New()
+	handler.NewBookHandler(service.NewBookService(repository.NewBookRepository(db))).RegisterRoutes(r)
+	return r
+}
+
+// bookTagNames returns the names of the book's tags as stored, sorted
+func bookTagNames(t *testing.T, db *gorm.DB, bookID uint) []string {
+	t.Helper()
+	tags, err := repository.NewBookRepository(db).GetTags(bookID)
+	if err != nil {
+		t.Fatalf("GetTags failed: %v", err)
+	}
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestSetBookTagsCreatesMissingTags(t *testing.T) {
+	db := newTestDB(t)
+	existing := model.Tag{Name: "go"}
+	db.Create(&existing)
+	book := seedTaggedBooks(t, db, 1, existing)[0]
+
+	w := adminRequest(newBookRouter(db), http.MethodPut, "/api/books/"+itoa(book.ID)+"/tags", "",
+		`{"tags": ["go", " databases ", "testing", "go"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var tags []model.Tag
+	if err := decodeData(w.Body.Bytes(), &tags); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(tags) != 3 {
+		t.Fatalf("Expected 3 de-duplicated tags, got %d", len(tags))
+	}
+	if tags[0].ID != existing.ID {
+		t.Errorf("Expected the existing go tag to be reused, got id %d", tags[0].ID)
+	}
+
+	got := bookTagNames(t, db, book.ID)
+	want := []string{"databases", "go", "testing"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("Expected book tags %v, got %v", want, got)
+	}
+	var tagCount int64
+	db.Model(&model.Tag{}).Count(&tagCount)
+	if tagCount != 3 {
+		t.Errorf("Expected 3 tags in total, got %d", tagCount)
+	}
+}
+
+func TestSetBookTagsRestoresDeletedTag(t *testing.T) {
+	db := newTestDB(t)
+	deleted := model.Tag{Name: "go"}
+	db.Create(&deleted)
+	db.Delete(&deleted) // Soft delete: the name stays in the unique index
+	book := seedTaggedBooks(t, db, 1, model.Tag{Name: "databases"})[0]
+
+	w := adminRequest(newBookRouter(db), http.MethodPut, "/api/books/"+itoa(book.ID)+"/tags", "",
+		`{"tags": ["go"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var restored model.Tag
+	if err := db.First(&restored, deleted.ID).Error; err != nil {
+		t.Fatalf("Expected deleted tag to be restored, got %v", err)
+	}
+	var tagCount int64
+	db.Unscoped().Model(&model.Tag{}).Where("name = ?", "go").Count(&tagCount)
+	if tagCount != 1 {
+		t.Errorf("Expected 1 go tag in total, got %d", tagCount)
+	}
+	if got := bookTagNames(t, db, book.ID); len(got) != 1 || got[0] != "go" {
+		t.Errorf("Expected book tags [go], got %v", got)
+	}
+}
+
+func TestSetBookTagsReplacesExistingSet(t *testing.T) {
+	db := newTestDB(t)
+	old := model.Tag{Name: "old"}
+	db.Create(&old)
+	books := seedTaggedBooks(t, db, 2, old)
+	r := newBookRouter(db)
+
+	w := adminRequest(r, http.MethodPut, "/api/books/"+itoa(books[0].ID)+"/tags", "", `{"tags": ["new"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := bookTagNames(t, db, books[0].ID); len(got) != 1 || got[0] != "new" {
+		t.Errorf("Expected tags [new], got %v", got)
+	}
+	if got := bookTagNames(t, db, books[1].ID); len(got) != 1 || got[0] != "old" {
+		t.Errorf("Expected other book to keep [old], got %v", got)
+	}
+
+	w = adminRequest(r, http.MethodPut, "/api/books/"+itoa(books[0].ID)+"/tags", "", `{"tags": []}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 clearing tags, got %d", w.Code)
+	}
+	if got := bookTagNames(t, db, books[0].ID); len(got) != 0 {
+		t.Errorf("Expected no tags after clearing, got %v", got)
+	}
+}
+
+func TestSetBookTagsErrors(t *testing.T) {
+	db := newTestDB(t)
+	tag := model.Tag{Name: "go"}
+	db.Create(&tag)
+	book := seedTaggedBooks(t, db, 1, tag)[0]
+	r := newBookRouter(db)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"unknown book", "/api/books/999/tags", `{"tags": ["go"]}`, http.StatusNotFound},
+		{"invalid id", "/api/books/abc/tags", `{"tags": ["go"]}`, http.StatusBadRequest},
+		{"missing tags field", "/api/books/" + itoa(book.ID) + "/tags", `{}`, http.StatusBadRequest},
+		{"blank tag name", "/api/books/" + itoa(book.ID) + "/tags", `{"tags": ["  "]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		w := adminRequest(r, http.MethodPut, tt.path, "", tt.body)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: Expected %d, got %d", tt.name, tt.wantStatus, w.Code)
+		}
+	}
+	if got := bookTagNames(t, db, book.ID); len(got) != 1 || got[0] != "go" {
+		t.Errorf("Expected failed requests to leave tags alone, got %v", got)
+	}
+}
+
+func TestRelatedBooksRankedBySharedTags(t *testing.T) {
+	db := newTestDB(t)
+	tags := make(map[string]model.Tag)
+	for _, name := range []string{"x", "y", "z", "w"} {
+		tag := model.Tag{Name: name}
+		db.Create(&tag)
+		tags[name] = tag
+	}
+	withTags := func(names ...string) []model.Tag {
+		var out []model.Tag
+		for _, n := range names {
+			out = append(out, tags[n])
+		}
+		return out
+	}
+
+	author := model.Author{Name: "Various", Books: []model.Book{
+		{Title: "Subject", ISBN: "a", Tags: withTags("x", "y", "z")},
+		{Title: "One shared", ISBN: "c", Tags: withTags("x")},
+		{Title: "Two shared", ISBN: "b", Tags: withTags("x", "y", "w")},
+		{Title: "Unrelated", ISBN: "d", Tags: withTags("w")},
+		{Title: "Deleted", ISBN: "e", Tags: withTags("x", "y", "z")},
+	}}
+	if err := db.Create(&author).Error; err != nil {
+		t.Fatalf("Failed to seed books: %v", err)
+	}
+	db.Delete(&author.Books[4])
+
+	w := adminRequest(newBookRouter(db), http.MethodGet, "/api/books/"+itoa(author.Books[0].ID)+"/related", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var related []struct {
+		Title      string `json:"title"`
+		SharedTags int64  `json:"shared_tags"`
+	}
+	if err := decodeData(w.Body.Bytes(), &related); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(related) != 2 {
+		t.Fatalf("Expected 2 related books, got %+v", related)
+	}
+	if related[0].Title != "Two shared" || related[0].SharedTags != 2 {
+		t.Errorf("Expected Two shared (2) first, got %+v", related[0])
+	}
+	if related[1].Title != "One shared" || related[1].SharedTags != 1 {
+		t.Errorf("Expected One shared (1) second, got %+v", related[1])
+	}
+}
+
+func TestRelatedBooksLimit(t *testing.T) {
+	db := newTestDB(t)
+	tag := model.Tag{Name: "x"}
+	db.Create(&tag)
+	books := make([]model.Book, 61)
+	for i := range books {
+		books[i] = model.Book{Title: "Book " + itoa(uint(i)), ISBN: "isbn-" + itoa(uint(i)), Tags: []model.Tag{tag}}
+	}
+	if err := db.Create(&model.Author{Name: "Prolific", Books: books}).Error; err != nil {
+		t.Fatalf("Failed to seed books: %v", err)
+	}
+	r := newBookRouter(db)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{"missing defaults to 10", "", 10},
+		{"zero defaults to 10", "?limit=0", 10},
+		{"within range", "?limit=5", 5},
+		{"above max clamps to 50", "?limit=100", 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := adminRequest(r, http.MethodGet, "/api/books/"+itoa(books[0].ID)+"/related"+tt.query, "", "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var related []model.RelatedBook
+			if err := decodeData(w.Body.Bytes(), &related); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(related) != tt.expected {
+				t.Errorf("Expected %d related books, got %d", tt.expected, len(related))
+			}
+		})
+	}
+}
+
+func TestRelatedBooksUnknownBook(t *testing.T) {
+	db := newTestDB(t)
+	w := adminRequest(newBookRouter(db), http.MethodGet, "/api/books/999/related", "", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
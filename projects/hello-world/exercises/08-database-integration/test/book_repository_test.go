@@ -0,0 +1,141 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"gorm.io/gorm"
+)
+
+// seedTaggedBooks creates n books titled "Book 01".. by one author, all tagged with tag
+func seedTaggedBooks(t *testing.T, db *gorm.DB, n int, tag model.Tag) []model.Book {
+	t.Helper()
+	author := model.Author{Name: "Prolific"}
+	for i := 1; i <= n; i++ {
+		author.Books = append(author.Books, model.Book{
+			Title: fmt.Sprintf("Book %02d", i),
+			ISBN:  fmt.Sprintf("isbn-%02d", i),
+			Tags:  []model.Tag{tag},
+		})
+	}
+	if err := db.Create(&author).Error; err != nil {
+		t.Fatalf("Failed to seed books: %v", err)
+	}
+	return author.Books
+}
+
+func TestFindByTagNamePaginated(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewBookRepository(db)
+
+	popular := model.Tag{Name: "popular"}
+	db.Create(&popular)
+	seedTaggedBooks(t, db, 30, popular)
+	db.Create(&model.Author{Name: "Other", Books: []model.Book{{Title: "Untagged", ISBN: "x"}}})
+
+	tests := []struct {
+		page, pageSize int
+		wantLen        int
+		wantFirst      string
+	}{
+		{1, 10, 10, "Book 01"},
+		{2, 10, 10, "Book 11"},
+		{3, 10, 10, "Book 21"},
+		{4, 10, 0, ""},
+		{2, 25, 5, "Book 26"},
+	}
+
+	for _, tt := range tests {
+		books, total, err := repo.FindByTagNamePaginated("popular", tt.page, tt.pageSize)
+		if err != nil {
+			t.Fatalf("page %d/%d: FindByTagNamePaginated failed: %v", tt.page, tt.pageSize, err)
+		}
+		if total != 30 {
+			t.Errorf("page %d/%d: Expected total 30, got %d", tt.page, tt.pageSize, total)
+		}
+		if len(books) != tt.wantLen {
+			t.Errorf("page %d/%d: Expected %d books, got %d", tt.page, tt.pageSize, tt.wantLen, len(books))
+			continue
+		}
+		if tt.wantLen > 0 && books[0].Title != tt.wantFirst {
+			t.Errorf("page %d/%d: Expected first book %s, got %s", tt.page, tt.pageSize, tt.wantFirst, books[0].Title)
+		}
+	}
+}
+
+func TestSearchByTitlePaginated(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewBookRepository(db)
+
+	tag := model.Tag{Name: "fiction"}
+	db.Create(&tag)
+	seedTaggedBooks(t, db, 30, tag)
+
+	// "Book 1" matches Book 10-19
+	books, total, err := repo.SearchByTitlePaginated("Book 1", 2, 4)
+	if err != nil {
+		t.Fatalf("SearchByTitlePaginated failed: %v", err)
+	}
+	if total != 10 {
+		t.Errorf("Expected total 10, got %d", total)
+	}
+	if len(books) != 4 || books[0].Title != "Book 14" {
+		t.Fatalf("Expected 4 books starting at Book 14, got %d", len(books))
+	}
+	if len(books[0].Tags) != 1 {
+		t.Errorf("Expected tags preloaded, got %+v", books[0].Tags)
+	}
+}
+
+func TestSearchByTitleTreatsWildcardsLiterally(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewBookRepository(db)
+
+	author := model.Author{Name: "Escaper", Books: []model.Book{
+		{Title: "100% Go", ISBN: "a"},
+		{Title: "1000 Recipes", ISBN: "b"},
+		{Title: "snake_case style", ISBN: "c"},
+		{Title: "snakeXcase style", ISBN: "d"},
+		{Title: `C:\go\bin`, ISBN: "e"},
+	}}
+	if err := db.Create(&author).Error; err != nil {
+		t.Fatalf("Failed to seed books: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"100%", []string{"100% Go"}},
+		{"%", []string{"100% Go"}},
+		{"e_c", []string{"snake_case style"}},
+		{`\go`, []string{`C:\go\bin`}},
+		{"style", []string{"snake_case style", "snakeXcase style"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			books, err := repo.SearchByTitle(tt.query)
+			if err != nil {
+				t.Fatalf("SearchByTitle failed: %v", err)
+			}
+			var got []string
+			for _, b := range books {
+				got = append(got, b.Title)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+
+			_, total, err := repo.SearchByTitlePaginated(tt.query, 1, 10)
+			if err != nil {
+				t.Fatalf("SearchByTitlePaginated failed: %v", err)
+			}
+			if total != int64(len(tt.want)) {
+				t.Errorf("Expected paginated total %d, got %d", len(tt.want), total)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/querybuilder"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+)
+
+func TestClientDisconnectCancelsRequestContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	started := make(chan struct{})
+	observed := make(chan error, 1)
+
+	r := gin.New()
+	r.Use(middleware.RequestID())
+	r.Use(middleware.ClientDisconnect(zap.New(core)))
+	r.GET("/slow", func(c *gin.Context) {
+		close(started)
+		select {
+		case <-c.Request.Context().Done():
+			observed <- c.Request.Context().Err()
+		case <-time.After(5 * time.Second):
+			observed <- nil
+			c.Status(http.StatusOK)
+		}
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	// Simulate the client giving up: cancel once the handler is running
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/slow", nil)
+	go func() {
+		<-started
+		cancel()
+	}()
+	if _, err := http.DefaultClient.Do(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected client error context.Canceled, got %v", err)
+	}
+
+	select {
+	case err := <-observed:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected handler to observe context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Handler never finished")
+	}
+
+	// The middleware logs after the handler returns
+	deadline := time.Now().Add(2 * time.Second)
+	for logs.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	entries := logs.FilterMessage("client disconnected, request cancelled").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 cancellation log entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["path"] != "/slow" || fields["method"] != http.MethodGet {
+		t.Errorf("Expected GET /slow in log fields, got %v", fields)
+	}
+	if fields["request_id"] == "" {
+		t.Error("Expected request_id in log fields")
+	}
+}
+
+func TestClientDisconnectIgnoresCompletedRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	r := gin.New()
+	r.Use(middleware.ClientDisconnect(zap.New(core)))
+	r.GET("/fast", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", w.Code)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("Expected no log entries, got %d", logs.Len())
+	}
+}
+
+// cancelOnQuery cancels the context of the next query GORM runs on db, just before it
+// reaches the driver, like a client hanging up while the query is being sent
+func cancelOnQuery(t *testing.T, db *gorm.DB) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	err := db.Callback().Query().Before("gorm:query").Register("test:cancel", func(*gorm.DB) { cancel() })
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	return ctx
+}
+
+func TestRepositoryQueriesAreCancelled(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func(repo repository.UserRepository, ctx context.Context) error
+	}{
+		{"FindAll", func(repo repository.UserRepository, ctx context.Context) error {
+			_, err := repo.FindAll(ctx)
+			return err
+		}},
+		{"FindByNameContaining", func(repo repository.UserRepository, ctx context.Context) error {
+			_, err := repo.FindByNameContaining(ctx, "User")
+			return err
+		}},
+		{"FindByQuery", func(repo repository.UserRepository, ctx context.Context) error {
+			_, _, err := repo.FindByQuery(ctx, &querybuilder.Query{Page: 1, PageSize: 10})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		db := newTestDB(t)
+		repo := repository.NewUserRepository(db)
+		if err := repo.CreateMany(makeUsers(5), 5); err != nil {
+			t.Fatalf("CreateMany failed: %v", err)
+		}
+
+		err := tt.query(repo, cancelOnQuery(t, db))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: Expected context.Canceled, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestListAndSearchUseRequestContext(t *testing.T) {
+	for _, path := range []string{"/api/users", "/api/users?page=1", "/api/users/search?q=User"} {
+		db := newTestDB(t)
+		if err := repository.NewUserRepository(db).CreateMany(makeUsers(5), 5); err != nil {
+			t.Fatalf("CreateMany failed: %v", err)
+		}
+
+		// Record whether the request's cancellation reached the SQL layer
+		var queryErr error
+		db.Callback().Query().Before("gorm:query").Register("test:observe", func(tx *gorm.DB) {
+			queryErr = tx.Statement.Context.Err()
+		})
+
+		core, logs := observer.New(zapcore.InfoLevel)
+		r := gin.New()
+		r.Use(middleware.ClientDisconnect(zap.New(core)))
+		handler.NewUserHandler(service.NewUserService(repository.NewUserRepository(db))).RegisterRoutes(r)
+
+		// The client is already gone when the handler runs
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx))
+
+		if !errors.Is(queryErr, context.Canceled) {
+			t.Errorf("%s: Expected the query to see context.Canceled, got %v", path, queryErr)
+		}
+		if w.Code == http.StatusOK {
+			t.Errorf("%s: Expected the cancelled query to fail, got 200", path)
+		}
+		if logs.FilterMessage("client disconnected, request cancelled").Len() != 1 {
+			t.Errorf("%s: Expected the disconnect to be logged", path)
+		}
+	}
+}
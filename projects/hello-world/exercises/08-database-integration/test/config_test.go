@@ -0,0 +1,96 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/config"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/response"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    config.PaginationConfig
+		wantErr string
+	}{
+		{"overrides both", "pagination:\n  default_size: 20\n  max_size: 200\n", config.PaginationConfig{DefaultSize: 20, MaxSize: 200}, ""},
+		{"partial override keeps defaults", "pagination:\n  max_size: 50\n", config.PaginationConfig{DefaultSize: 10, MaxSize: 50}, ""},
+		{"max below default", "pagination:\n  default_size: 20\n  max_size: 5\n", config.PaginationConfig{}, "max_size"},
+		{"zero default", "pagination:\n  default_size: 0\n", config.PaginationConfig{}, "default_size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.Load(writeFixture(t, "config.yaml", tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error mentioning %s, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if cfg.Pagination != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, cfg.Pagination)
+			}
+		})
+	}
+}
+
+func TestLoadConfigMissingFileUsesDefaults(t *testing.T) {
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "absent.yaml"))
+	if err != nil {
+		t.Fatalf("Expected defaults for a missing file, got %v", err)
+	}
+	if cfg.Pagination != config.DefaultPagination() {
+		t.Errorf("Expected default pagination, got %+v", cfg.Pagination)
+	}
+}
+
+func TestPageSizeClampedToConfiguredMax(t *testing.T) {
+	db := newTestDB(t)
+	for _, u := range makeUsers(8) {
+		seedUser(t, db, u.Email)
+	}
+	svc := service.NewUserService(repository.NewUserRepository(db),
+		service.WithPagination(config.PaginationConfig{DefaultSize: 3, MaxSize: 5}))
+	r := gin.New()
+	handler.NewUserHandler(svc).RegisterRoutes(r)
+
+	tests := []struct {
+		query    string
+		wantSize int
+	}{
+		{"page_size=50", 5}, // over the max: clamped, not reset to the default
+		{"page_size=4", 4},
+		{"page=1", 3}, // missing: the configured default
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users?"+tt.query, nil))
+
+			var resp struct {
+				Data []map[string]interface{} `json:"data"`
+				Meta response.PageMeta        `json:"meta"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if resp.Meta.PageSize != tt.wantSize || len(resp.Data) != tt.wantSize {
+				t.Errorf("Expected page size %d, got meta %d with %d users", tt.wantSize, resp.Meta.PageSize, len(resp.Data))
+			}
+		})
+	}
+}
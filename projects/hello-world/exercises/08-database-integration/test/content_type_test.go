@@ -0,0 +1,52 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+)
+
+func TestRequireJSON(t *testing.T) {
+	r := gin.New()
+	r.Use(middleware.RequireJSON())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.POST("/things", ok)
+	r.PUT("/things", ok)
+	r.GET("/things", ok)
+	r.DELETE("/things", ok)
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		wantStatus  int
+	}{
+		{"POST without content type", http.MethodPost, "", http.StatusUnsupportedMediaType},
+		{"PUT with form content type", http.MethodPut, "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"POST with malformed content type", http.MethodPost, "application/json; charset", http.StatusUnsupportedMediaType},
+		{"POST with JSON", http.MethodPost, "application/json", http.StatusOK},
+		{"PUT with JSON and charset", http.MethodPut, "application/json; charset=utf-8", http.StatusOK},
+		{"POST with +json suffix", http.MethodPost, "application/merge-patch+json", http.StatusOK},
+		{"GET is not checked", http.MethodGet, "", http.StatusOK},
+		{"DELETE is not checked", http.MethodDelete, "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/things", strings.NewReader(`{}`))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
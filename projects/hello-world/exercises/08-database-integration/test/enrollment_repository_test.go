@@ -0,0 +1,413 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"gorm.io/gorm"
+)
+
+// seedCourse inserts a course with the given code and credits
+func seedCourse(t *testing.T, db *gorm.DB, code string, credits int) *model.Course {
+	t.Helper()
+	course := &model.Course{Name: code, Code: code, Credits: credits, MaxStudents: 30}
+	if err := db.Create(course).Error; err != nil {
+		t.Fatalf("Failed to seed course %s: %v", code, err)
+	}
+	return course
+}
+
+// seedStudent inserts a student with the given code
+func seedStudent(t *testing.T, db *gorm.DB, code string) *model.Student {
+	t.Helper()
+	student := &model.Student{Name: code, StudentCode: code, Email: code + "@university.edu"}
+	if err := db.Create(student).Error; err != nil {
+		t.Fatalf("Failed to seed student %s: %v", code, err)
+	}
+	return student
+}
+
+func TestStudentTranscript(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	student := seedStudent(t, db, "STU001")
+	other := seedStudent(t, db, "STU002")
+
+	grade := func(g string) *string { return &g }
+	enrollments := []struct {
+		code      string
+		credits   int
+		grade     *string
+		completed bool
+	}{
+		{"CS101", 3, grade("A"), true},  // 12 points, completed
+		{"CS201", 4, grade("B"), false}, // 12 points, still in progress
+		{"CS301", 3, nil, false},        // ungraded: attempted, no GPA weight
+		{"CS401", 2, grade("F"), true},  // 0 points, failed so not completed
+	}
+	start := time.Now().AddDate(0, -4, 0)
+	for i, e := range enrollments {
+		course := seedCourse(t, db, e.code, e.credits)
+		db.Create(&model.Enrollment{
+			StudentID: student.ID, CourseID: course.ID, Grade: e.grade, Completed: e.completed,
+			EnrolledAt: start.AddDate(0, i, 0),
+		})
+		db.Create(&model.Enrollment{StudentID: other.ID, CourseID: course.ID, Grade: grade("A"), EnrolledAt: start})
+	}
+
+	transcript, err := repo.StudentTranscript(student.ID)
+	if err != nil {
+		t.Fatalf("StudentTranscript failed: %v", err)
+	}
+
+	if len(transcript.Courses) != 4 {
+		t.Fatalf("Expected 4 courses, got %d", len(transcript.Courses))
+	}
+	if transcript.Courses[0].Code != "CS101" || transcript.Courses[2].Grade != nil {
+		t.Errorf("Expected courses in enrollment order with CS301 ungraded, got %+v", transcript.Courses)
+	}
+	if transcript.CreditsAttempted != 12 {
+		t.Errorf("Expected 12 credits attempted, got %d", transcript.CreditsAttempted)
+	}
+	if transcript.CreditsCompleted != 3 {
+		t.Errorf("Expected 3 credits completed, got %d", transcript.CreditsCompleted)
+	}
+	// (4*3 + 3*4 + 0*2) / (3 + 4 + 2)
+	if want := 24.0 / 9.0; math.Abs(transcript.GPA-want) > 1e-9 {
+		t.Errorf("Expected GPA %.4f, got %.4f", want, transcript.GPA)
+	}
+}
+
+func TestStudentTranscriptWithoutGrades(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	student := seedStudent(t, db, "STU001")
+	course := seedCourse(t, db, "CS101", 3)
+	db.Create(&model.Enrollment{StudentID: student.ID, CourseID: course.ID, EnrolledAt: time.Now()})
+
+	transcript, err := repo.StudentTranscript(student.ID)
+	if err != nil {
+		t.Fatalf("StudentTranscript failed: %v", err)
+	}
+	if transcript.GPA != 0 || transcript.CreditsAttempted != 3 || transcript.CreditsCompleted != 0 {
+		t.Errorf("Expected GPA 0 with 3 credits attempted, got %+v", transcript)
+	}
+
+	if _, err := repo.StudentTranscript(999); err == nil {
+		t.Error("Expected error for unknown student, got nil")
+	}
+}
+
+func TestWaitlistPromotionOnUnenroll(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	course := &model.Course{Name: "Tiny", Code: "CS999", Credits: 3, MaxStudents: 2}
+	db.Create(course)
+
+	students := make([]*model.Student, 4)
+	for i := range students {
+		students[i] = seedStudent(t, db, fmt.Sprintf("STU%03d", i+1))
+	}
+
+	// Fill the course
+	for _, s := range students[:2] {
+		if _, err := repo.Enroll(s.ID, course.ID); err != nil {
+			t.Fatalf("Enroll failed: %v", err)
+		}
+	}
+	if _, err := repo.Enroll(students[2].ID, course.ID); !errors.Is(err, repository.ErrCourseFull) {
+		t.Fatalf("Expected ErrCourseFull, got %v", err)
+	}
+
+	// Waitlist two students, in order
+	for _, s := range students[2:] {
+		if _, err := repo.JoinWaitlist(s.ID, course.ID); err != nil {
+			t.Fatalf("JoinWaitlist failed: %v", err)
+		}
+	}
+	if _, err := repo.JoinWaitlist(students[2].ID, course.ID); err == nil {
+		t.Error("Expected error joining the waitlist twice, got nil")
+	}
+	if _, err := repo.JoinWaitlist(students[0].ID, course.ID); err == nil {
+		t.Error("Expected error waitlisting an enrolled student, got nil")
+	}
+	if promoted, err := repo.PromoteFromWaitlist(course.ID); err != nil || promoted != nil {
+		t.Errorf("Expected no promotion while full, got %v (err %v)", promoted, err)
+	}
+
+	// A seat opens: the earliest waiter gets it
+	if err := repo.Unenroll(students[0].ID, course.ID); err != nil {
+		t.Fatalf("Unenroll failed: %v", err)
+	}
+	if e, _ := repo.FindByStudentAndCourse(students[2].ID, course.ID); e == nil {
+		t.Error("Expected first waitlisted student to be enrolled")
+	}
+	if e, _ := repo.FindByStudentAndCourse(students[3].ID, course.ID); e != nil {
+		t.Error("Expected second waitlisted student to still be waiting")
+	}
+
+	waitlist, err := repo.FindWaitlist(course.ID)
+	if err != nil {
+		t.Fatalf("FindWaitlist failed: %v", err)
+	}
+	if len(waitlist) != 1 || waitlist[0].StudentID != students[3].ID {
+		t.Errorf("Expected only the second student left on the waitlist, got %+v", waitlist)
+	}
+	if count, _ := repo.CountStudentsInCourse(course.ID); count != 2 {
+		t.Errorf("Expected course to stay at capacity 2, got %d", count)
+	}
+}
+
+func TestWaitlistPromotionSkipsScheduleConflicts(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	course := &model.Course{Name: "Tiny", Code: "CS999", MaxStudents: 1, StartTime: "10:00", EndTime: "11:00", DaysOfWeek: "Mon"}
+	clash := &model.Course{Name: "Clash", Code: "CS500", MaxStudents: 30, StartTime: "10:30", EndTime: "11:30", DaysOfWeek: "Mon"}
+	db.Create(course)
+	db.Create(clash)
+	holder, busy, free := seedStudent(t, db, "STU001"), seedStudent(t, db, "STU002"), seedStudent(t, db, "STU003")
+
+	if _, err := repo.Enroll(holder.ID, course.ID); err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+	if _, err := repo.Enroll(busy.ID, clash.ID); err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+	// busy is first in line but already takes a course at the same time
+	for _, s := range []*model.Student{busy, free} {
+		if _, err := repo.JoinWaitlist(s.ID, course.ID); err != nil {
+			t.Fatalf("JoinWaitlist failed: %v", err)
+		}
+	}
+
+	if err := repo.Unenroll(holder.ID, course.ID); err != nil {
+		t.Fatalf("Unenroll failed: %v", err)
+	}
+	if e, _ := repo.FindByStudentAndCourse(busy.ID, course.ID); e != nil {
+		t.Error("Expected the clashing student not to be promoted")
+	}
+	if e, _ := repo.FindByStudentAndCourse(free.ID, course.ID); e == nil {
+		t.Error("Expected the next student without a clash to be promoted")
+	}
+	waitlist, _ := repo.FindWaitlist(course.ID)
+	if len(waitlist) != 1 || waitlist[0].StudentID != busy.ID {
+		t.Errorf("Expected the clashing student to keep their place, got %+v", waitlist)
+	}
+
+	// Only the clashing student is left: a free seat stays free
+	if err := repo.Unenroll(free.ID, course.ID); err != nil {
+		t.Fatalf("Unenroll failed: %v", err)
+	}
+	if count, _ := repo.CountStudentsInCourse(course.ID); count != 0 {
+		t.Errorf("Expected the seat to stay empty, got %d enrolled", count)
+	}
+}
+
+func TestEnrollRejectsScheduleConflictWithoutService(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	student := seedStudent(t, db, "STU001")
+	a := &model.Course{Name: "A", Code: "CS101", MaxStudents: 30, StartTime: "09:00", EndTime: "10:00", DaysOfWeek: "Tue"}
+	b := &model.Course{Name: "B", Code: "CS102", MaxStudents: 30, StartTime: "09:30", EndTime: "10:30", DaysOfWeek: "Tue"}
+	db.Create(a)
+	db.Create(b)
+
+	if _, err := repo.Enroll(student.ID, a.ID); err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+	var conflict *repository.ScheduleConflictError
+	if _, err := repo.Enroll(student.ID, b.ID); !errors.As(err, &conflict) {
+		t.Errorf("Expected ScheduleConflictError, got %v", err)
+	}
+}
+
+func TestJoinWaitlistRequiresFullCourse(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	course := seedCourse(t, db, "CS101", 3)
+	student := seedStudent(t, db, "STU001")
+
+	if _, err := repo.JoinWaitlist(student.ID, course.ID); err == nil {
+		t.Error("Expected error waitlisting for a course with open seats, got nil")
+	}
+}
+
+func TestAverageGradeExcludesSoftDeletedEnrollments(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	course := seedCourse(t, db, "CS101", 3)
+
+	grades := []string{"A", "C", "F"} // the F is dropped below
+	for i, g := range grades {
+		student := seedStudent(t, db, fmt.Sprintf("STU%03d", i+1))
+		grade := g
+		db.Create(&model.Enrollment{StudentID: student.ID, CourseID: course.ID, Grade: &grade, EnrolledAt: time.Now()})
+	}
+	if err := db.Where("grade = ?", "F").Delete(&model.Enrollment{}).Error; err != nil {
+		t.Fatalf("Soft delete failed: %v", err)
+	}
+
+	avg, err := repo.GetAverageGradeForCourse(course.ID)
+	if err != nil {
+		t.Fatalf("GetAverageGradeForCourse failed: %v", err)
+	}
+	if avg != 3.0 { // (4 + 2) / 2
+		t.Errorf("Expected average 3.0 without the soft-deleted F, got %v", avg)
+	}
+}
+
+func TestRawQueryWithoutSoftDeleteCheckIsRejected(t *testing.T) {
+	db := newTestDB(t)
+
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM enrollments").Scan(&count).Error; err == nil {
+		t.Error("Expected the test guard to reject a raw query ignoring deleted_at, got nil")
+	}
+	if err := db.Raw("SELECT COUNT(*) FROM enrollments WHERE deleted_at IS NULL").Scan(&count).Error; err != nil {
+		t.Errorf("Expected raw query with deleted_at check to pass, got %v", err)
+	}
+}
+
+func TestUpdateGradeIfUnset(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	student := seedStudent(t, db, "STU001")
+	course := seedCourse(t, db, "CS101", 3)
+	if _, err := repo.Enroll(student.ID, course.ID); err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+
+	// First grader wins
+	if err := repo.UpdateGradeIfUnset(student.ID, course.ID, "B"); err != nil {
+		t.Fatalf("Expected unset grade to be set, got %v", err)
+	}
+
+	// Second grader conflicts and doesn't overwrite
+	err := repo.UpdateGradeIfUnset(student.ID, course.ID, "A")
+	if !errors.Is(err, repository.ErrGradeConflict) {
+		t.Fatalf("Expected ErrGradeConflict, got %v", err)
+	}
+	e, _ := repo.FindByStudentAndCourse(student.ID, course.ID)
+	if e.Grade == nil || *e.Grade != "B" {
+		t.Errorf("Expected grade to stay B, got %v", e.Grade)
+	}
+
+	// The force variant still overwrites
+	if err := repo.UpdateGrade(student.ID, course.ID, "A"); err != nil {
+		t.Fatalf("UpdateGrade failed: %v", err)
+	}
+	e, _ = repo.FindByStudentAndCourse(student.ID, course.ID)
+	if e.Grade == nil || *e.Grade != "A" {
+		t.Errorf("Expected forced grade A, got %v", e.Grade)
+	}
+
+	if err := repo.UpdateGradeIfUnset(student.ID, 999, "A"); err == nil || errors.Is(err, repository.ErrGradeConflict) {
+		t.Errorf("Expected not-found error for a missing enrollment, got %v", err)
+	}
+}
+
+func TestEnrollUsesInjectedClock(t *testing.T) {
+	db := newTestDB(t)
+	fixed := time.Date(2024, 9, 2, 8, 30, 0, 0, time.UTC)
+	clock := repository.NewFakeClock(fixed)
+	repo := repository.NewEnrollmentRepositoryWithClock(db, clock)
+
+	student := seedStudent(t, db, "STU001")
+	first := seedCourse(t, db, "CS101", 3)
+	second := seedCourse(t, db, "CS102", 3)
+
+	if _, err := repo.Enroll(student.ID, first.ID); err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+	clock.Advance(24 * time.Hour)
+	if _, err := repo.Enroll(student.ID, second.ID); err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+
+	tests := []struct {
+		courseID uint
+		want     time.Time
+	}{
+		{first.ID, fixed},
+		{second.ID, fixed.Add(24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		// Read back from the database, not the returned struct
+		var stored model.Enrollment
+		if err := db.Where("student_id = ? AND course_id = ?", student.ID, tt.courseID).First(&stored).Error; err != nil {
+			t.Fatalf("Failed to load enrollment: %v", err)
+		}
+		if !stored.EnrolledAt.Equal(tt.want) {
+			t.Errorf("Expected EnrolledAt %v, got %v", tt.want, stored.EnrolledAt)
+		}
+	}
+}
+
+func TestWaitlistPromotionRevivesUnenrolledStudent(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	course := &model.Course{Name: "Solo", Code: "CS998", Credits: 3, MaxStudents: 1}
+	db.Create(course)
+	a := seedStudent(t, db, "STU001")
+	b := seedStudent(t, db, "STU002")
+
+	// B takes the seat, leaves (soft delete), A takes it, B waits for it again
+	if _, err := repo.Enroll(b.ID, course.ID); err != nil {
+		t.Fatalf("Enroll B failed: %v", err)
+	}
+	grade := "F"
+	if err := repo.UpdateGrade(b.ID, course.ID, grade); err != nil {
+		t.Fatalf("UpdateGrade failed: %v", err)
+	}
+	if err := repo.Unenroll(b.ID, course.ID); err != nil {
+		t.Fatalf("Unenroll B failed: %v", err)
+	}
+	if _, err := repo.Enroll(a.ID, course.ID); err != nil {
+		t.Fatalf("Enroll A failed: %v", err)
+	}
+	if _, err := repo.JoinWaitlist(b.ID, course.ID); err != nil {
+		t.Fatalf("JoinWaitlist B failed: %v", err)
+	}
+
+	if err := repo.Unenroll(a.ID, course.ID); err != nil {
+		t.Fatalf("Unenroll A failed: %v", err)
+	}
+	if e, _ := repo.FindByStudentAndCourse(a.ID, course.ID); e != nil {
+		t.Error("Expected A to be unenrolled")
+	}
+	e, _ := repo.FindByStudentAndCourse(b.ID, course.ID)
+	if e == nil {
+		t.Fatal("Expected B to be promoted")
+	}
+	if e.Grade != nil || e.Completed {
+		t.Errorf("Expected a fresh enrollment, got grade %v completed %v", e.Grade, e.Completed)
+	}
+	if waitlist, _ := repo.FindWaitlist(course.ID); len(waitlist) != 0 {
+		t.Errorf("Expected empty waitlist, got %+v", waitlist)
+	}
+}
+
+func TestReenrollAfterUnenroll(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewEnrollmentRepository(db)
+	course := seedCourse(t, db, "CS101", 3)
+	student := seedStudent(t, db, "STU001")
+
+	if _, err := repo.Enroll(student.ID, course.ID); err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+	if err := repo.Unenroll(student.ID, course.ID); err != nil {
+		t.Fatalf("Unenroll failed: %v", err)
+	}
+	if _, err := repo.Enroll(student.ID, course.ID); err != nil {
+		t.Errorf("Expected re-enrollment to succeed, got %v", err)
+	}
+	if count, _ := repo.CountStudentsInCourse(course.ID); count != 1 {
+		t.Errorf("Expected 1 student, got %d", count)
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+)
+
+func TestEnrollScheduleConflicts(t *testing.T) {
+	existing := model.Course{Code: "CS101", StartTime: "10:00", EndTime: "11:30", DaysOfWeek: "Mon,Wed"}
+
+	tests := []struct {
+		name     string
+		course   model.Course
+		conflict bool
+	}{
+		{"same slot", model.Course{StartTime: "10:00", EndTime: "11:30", DaysOfWeek: "Mon"}, true},
+		{"partial overlap", model.Course{StartTime: "11:00", EndTime: "12:00", DaysOfWeek: "Wed,Fri"}, true},
+		{"contained", model.Course{StartTime: "10:15", EndTime: "10:45", DaysOfWeek: "Wednesday"}, true},
+		{"back to back", model.Course{StartTime: "11:30", EndTime: "12:30", DaysOfWeek: "Mon,Wed"}, false},
+		{"same time other days", model.Course{StartTime: "10:00", EndTime: "11:30", DaysOfWeek: "Tue,Thu"}, false},
+		{"unscheduled", model.Course{}, false},
+	}
+
+	for _, tt := range tests {
+		db := newTestDB(t)
+		repo := repository.NewEnrollmentRepository(db)
+		svc := service.NewEnrollmentService(repo)
+		student := seedStudent(t, db, "STU001")
+
+		first := existing
+		first.Name, first.MaxStudents = first.Code, 30
+		db.Create(&first)
+		if _, err := svc.Enroll(student.ID, first.ID); err != nil {
+			t.Fatalf("%s: first Enroll failed: %v", tt.name, err)
+		}
+
+		second := tt.course
+		second.Name, second.Code, second.MaxStudents = "CS202", "CS202", 30
+		db.Create(&second)
+		_, err := svc.Enroll(student.ID, second.ID)
+
+		var conflict *repository.ScheduleConflictError
+		if tt.conflict {
+			if !errors.As(err, &conflict) {
+				t.Errorf("%s: Expected ScheduleConflictError, got %v", tt.name, err)
+				continue
+			}
+			if conflict.ConflictsWith.Code != "CS101" {
+				t.Errorf("%s: Expected conflict with CS101, got %s", tt.name, conflict.ConflictsWith.Code)
+			}
+			if e, _ := repo.FindByStudentAndCourse(student.ID, second.ID); e != nil {
+				t.Errorf("%s: Expected no enrollment after a conflict", tt.name)
+			}
+		} else if err != nil {
+			t.Errorf("%s: Expected enrollment to succeed, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestEnrollConflictErrorDescribesBothCourses(t *testing.T) {
+	db := newTestDB(t)
+	svc := service.NewEnrollmentService(repository.NewEnrollmentRepository(db))
+	student := seedStudent(t, db, "STU001")
+
+	a := model.Course{Name: "A", Code: "CS101", MaxStudents: 30, StartTime: "09:00", EndTime: "10:00", DaysOfWeek: "Mon"}
+	b := model.Course{Name: "B", Code: "CS102", MaxStudents: 30, StartTime: "09:30", EndTime: "10:30", DaysOfWeek: "Mon"}
+	db.Create(&a)
+	db.Create(&b)
+	svc.Enroll(student.ID, a.ID)
+
+	_, err := svc.Enroll(student.ID, b.ID)
+	want := "CS102 (Mon 09:30-10:30) conflicts with CS101 (Mon 09:00-10:00)"
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected %q, got %v", want, err)
+	}
+}
+
+func TestEnrollRejectsInvalidSchedule(t *testing.T) {
+	db := newTestDB(t)
+	svc := service.NewEnrollmentService(repository.NewEnrollmentRepository(db))
+	student := seedStudent(t, db, "STU001")
+
+	course := model.Course{Name: "Bad", Code: "CS999", MaxStudents: 30, StartTime: "11:00", EndTime: "10:00", DaysOfWeek: "Mon"}
+	db.Create(&course)
+
+	if _, err := svc.Enroll(student.ID, course.ID); err == nil {
+		t.Error("Expected error for end time before start time, got nil")
+	}
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+)
+
+func newGzipRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.Gzip(gzip.DefaultCompression))
+
+	large := make([]gin.H, 200)
+	for i := range large {
+		large[i] = gin.H{"id": i, "name": "User", "email": "user@example.com"}
+	}
+	r.GET("/large", func(c *gin.Context) { c.JSON(http.StatusOK, large) })
+	r.GET("/tiny", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	r.GET("/png", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", make([]byte, 4*middleware.GzipMinSize))
+	})
+	return r
+}
+
+func TestGzip(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantGzip       bool
+	}{
+		{"large JSON is compressed", "/large", "gzip, deflate", true},
+		{"tiny JSON is not compressed", "/tiny", "gzip", false},
+		{"client without gzip support", "/large", "", false},
+		{"already compressed content type", "/png", "gzip", false},
+	}
+
+	r := newGzipRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d", w.Code)
+			}
+			gotGzip := w.Header().Get("Content-Encoding") == "gzip"
+			if gotGzip != tt.wantGzip {
+				t.Fatalf("Expected gzip=%v, got Content-Encoding %q", tt.wantGzip, w.Header().Get("Content-Encoding"))
+			}
+
+			body := w.Body.Bytes()
+			if gotGzip {
+				gz, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("Expected valid gzip body, got %v", err)
+				}
+				if body, err = io.ReadAll(gz); err != nil {
+					t.Fatalf("Failed to decompress body: %v", err)
+				}
+				if w.Body.Len() >= len(body) {
+					t.Errorf("Expected compressed size < %d, got %d", len(body), w.Body.Len())
+				}
+			}
+			if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") && body[0] != '[' && body[0] != '{' {
+				t.Errorf("Expected JSON body, got %q", body[:10])
+			}
+		})
+	}
+}
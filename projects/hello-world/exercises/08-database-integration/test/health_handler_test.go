@@ -0,0 +1,132 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+)
+
+func passingCheck(ctx context.Context) error { return nil }
+
+func failingCheck(ctx context.Context) error { return errors.New("connection refused") }
+
+func hangingCheck(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestHealthChecker(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]handler.CheckFunc
+		wantStatus int
+		wantReport string
+		wantChecks map[string]string
+	}{
+		{
+			name:       "all checks pass",
+			checks:     map[string]handler.CheckFunc{"database": passingCheck, "disk": passingCheck},
+			wantStatus: http.StatusOK,
+			wantReport: handler.StatusUp,
+			wantChecks: map[string]string{"database": handler.StatusUp, "disk": handler.StatusUp},
+		},
+		{
+			name:       "one check fails",
+			checks:     map[string]handler.CheckFunc{"database": passingCheck, "downstream": failingCheck},
+			wantStatus: http.StatusServiceUnavailable,
+			wantReport: handler.StatusDown,
+			wantChecks: map[string]string{"database": handler.StatusUp, "downstream": handler.StatusDown},
+		},
+		{
+			name:       "check times out",
+			checks:     map[string]handler.CheckFunc{"slow": hangingCheck},
+			wantStatus: http.StatusServiceUnavailable,
+			wantReport: handler.StatusDown,
+			wantChecks: map[string]string{"slow": handler.StatusDown},
+		},
+		{
+			name:       "no checks registered",
+			checks:     nil,
+			wantStatus: http.StatusOK,
+			wantReport: handler.StatusUp,
+			wantChecks: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := handler.NewHealthChecker(50 * time.Millisecond)
+			for name, check := range tt.checks {
+				checker.Register(name, check)
+			}
+			r := gin.New()
+			checker.RegisterRoutes(r)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var report handler.HealthReport
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("Failed to decode report: %v", err)
+			}
+			if report.Status != tt.wantReport {
+				t.Errorf("Expected overall %s, got %s", tt.wantReport, report.Status)
+			}
+			if len(report.Checks) != len(tt.wantChecks) {
+				t.Fatalf("Expected %d checks, got %+v", len(tt.wantChecks), report.Checks)
+			}
+			for name, want := range tt.wantChecks {
+				got := report.Checks[name]
+				if got.Status != want {
+					t.Errorf("Expected %s to be %s, got %+v", name, want, got)
+				}
+				if want == handler.StatusDown && got.Error == "" {
+					t.Errorf("Expected error detail for %s, got none", name)
+				}
+			}
+		})
+	}
+}
+
+func TestLivenessIgnoresFailingDatabase(t *testing.T) {
+	db := newTestDB(t)
+	checker := handler.NewHealthChecker(time.Second)
+	checker.Register("database", database.Ping)
+	r := gin.New()
+	checker.RegisterRoutes(r)
+
+	// Simulate the database going away after startup
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	sqlDB.Close()
+
+	tests := []struct {
+		path       string
+		wantStatus int
+	}{
+		{"/livez", http.StatusOK},
+		{"/readyz", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
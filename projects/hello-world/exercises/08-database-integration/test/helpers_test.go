@@ -0,0 +1,114 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestDB opens a fresh SQLite file per test, migrates it and makes it the global database.DB
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+
+	database.DB = db
+	if err := database.AutoMigrate(); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	guardRawSoftDelete(t, db)
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// seedUser inserts a minimal user with the given email
+func seedUser(t *testing.T, db *gorm.DB, email string) *model.User {
+	t.Helper()
+	user := &model.User{Name: email, Email: email}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("Failed to seed user %s: %v", email, err)
+	}
+	return user
+}
+
+// guardRawSoftDelete fails raw queries that read soft-deletable tables without a deleted_at check
+// GORM only filters soft-deleted rows in queries it builds itself (see repository/soft_delete.go)
+func guardRawSoftDelete(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	var tables []string
+	err := db.Raw(`SELECT m.name FROM sqlite_master m, pragma_table_info(m.name) p
+		WHERE m.type = 'table' AND p.name = 'deleted_at'`).Scan(&tables).Error
+	if err != nil {
+		t.Fatalf("Failed to list soft-deletable tables: %v", err)
+	}
+
+	patterns := make(map[string]*regexp.Regexp, len(tables))
+	for _, table := range tables {
+		patterns[table] = regexp.MustCompile("(?i)\\b(from|join)\\s+[\"`]?" + table + "\\b")
+	}
+
+	guard := func(tx *gorm.DB) {
+		if tx.Statement.SQL.Len() == 0 {
+			return // built by GORM, which adds the soft-delete filter itself
+		}
+		sql := tx.Statement.SQL.String()
+		var read []string
+		for table, pattern := range patterns {
+			if pattern.MatchString(sql) {
+				read = append(read, table)
+			}
+		}
+		if len(read) > strings.Count(strings.ToLower(sql), "deleted_at") {
+			tx.AddError(fmt.Errorf("raw query reads soft-deletable %v without a deleted_at check: %s", read, sql))
+		}
+	}
+	db.Callback().Query().Before("gorm:query").Register("test:raw_soft_delete", guard)
+	db.Callback().Row().Before("gorm:row").Register("test:raw_soft_delete", guard)
+}
+
+// explainQuery returns SQLite's EXPLAIN QUERY PLAN for query, one step per line
+// e.g. "SEARCH users USING INDEX idx_users_email (email=?)"; "" on other dialects
+func explainQuery(db *gorm.DB, query string, args ...interface{}) string {
+	if db.Dialector.Name() != "sqlite" {
+		return ""
+	}
+	rows, err := db.Raw("EXPLAIN QUERY PLAN "+query, args...).Rows()
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	defer rows.Close()
+
+	var steps []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return "error: " + err.Error()
+		}
+		steps = append(steps, detail)
+	}
+	return strings.Join(steps, "\n")
+}
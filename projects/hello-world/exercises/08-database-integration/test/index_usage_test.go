@@ -0,0 +1,64 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// capturedSQL runs fn against a session that records every statement, and returns them
+func capturedSQL(db *gorm.DB, fn func(db *gorm.DB)) []string {
+	core, logs := observer.New(zapcore.DebugLevel)
+	zl := database.NewZapLogger(zap.New(core), time.Hour)
+	fn(db.Session(&gorm.Session{Logger: zl.LogMode(gormlogger.Info)}))
+
+	var statements []string
+	for _, entry := range logs.FilterMessage("query").All() {
+		statements = append(statements, entry.ContextMap()["sql"].(string))
+	}
+	return statements
+}
+
+func TestFindByEmailUsesEmailIndex(t *testing.T) {
+	db := newTestDB(t)
+	if db.Dialector.Name() != "sqlite" {
+		t.Skip("EXPLAIN QUERY PLAN output is SQLite specific")
+	}
+	seedUser(t, db, "indexed@example.com")
+
+	statements := capturedSQL(db, func(db *gorm.DB) {
+		repository.NewUserRepository(db).FindByEmail("indexed@example.com")
+	})
+	if len(statements) != 1 {
+		t.Fatalf("Expected FindByEmail to run 1 statement, got %v", statements)
+	}
+
+	plan := explainQuery(db, statements[0])
+	if !strings.Contains(plan, "USING INDEX idx_users_email_lookup") {
+		t.Errorf("Expected FindByEmail to search idx_users_email_lookup, got plan:\n%s", plan)
+	}
+	if strings.Contains(plan, "SCAN users") {
+		t.Errorf("Expected no full scan of users, got plan:\n%s", plan)
+	}
+}
+
+func TestExplainQueryDetectsFullScan(t *testing.T) {
+	db := newTestDB(t)
+	if db.Dialector.Name() != "sqlite" {
+		t.Skip("EXPLAIN QUERY PLAN output is SQLite specific")
+	}
+
+	// applied_at has no index, so this has to read every row
+	plan := explainQuery(db, "SELECT * FROM schema_migrations WHERE applied_at < ?", time.Now())
+	if !strings.Contains(plan, "SCAN schema_migrations") {
+		t.Errorf("Expected a full scan, got plan:\n%s", plan)
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// slowUserService sleeps in GetAll and answers everything else immediately
+type slowUserService struct {
+	service.UserService
+	delay time.Duration
+}
+
+func (s slowUserService) GetAll(ctx context.Context) ([]model.User, error) {
+	time.Sleep(s.delay)
+	return []model.User{{Name: "Slow"}}, nil
+}
+
+func (s slowUserService) GetByID(id uint) (*model.User, error) {
+	return &model.User{Name: "Fast"}, nil
+}
+
+func TestLoggingUserServiceWarnsOnSlowCalls(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	svc := service.NewLoggingUserService(slowUserService{delay: 30 * time.Millisecond}, zap.New(core), 20*time.Millisecond)
+
+	users, err := svc.GetAll(context.Background())
+	if err != nil || len(users) != 1 {
+		t.Fatalf("Expected the wrapped result, got %v, %v", users, err)
+	}
+	if _, err := svc.GetByID(1); err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 slow-call entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Level != zapcore.WarnLevel || entry.Message != "slow service call" {
+		t.Errorf("Expected warn 'slow service call', got %s %q", entry.Level, entry.Message)
+	}
+	fields := entry.ContextMap()
+	if fields["method"] != "UserService.GetAll" {
+		t.Errorf("Expected method UserService.GetAll, got %v", fields["method"])
+	}
+	if d, ok := fields["duration"].(time.Duration); !ok || d < 30*time.Millisecond {
+		t.Errorf("Expected duration of at least 30ms, got %v", fields["duration"])
+	}
+}
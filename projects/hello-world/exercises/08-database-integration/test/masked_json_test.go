@@ -0,0 +1,77 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+)
+
+type apiToken struct {
+	Name   string `json:"name"`
+	Secret string `json:"secret" mask:"true"`
+}
+
+type account struct {
+	model.User
+	PasswordHash string     `json:"password_hash" mask:"true"`
+	Tokens       []apiToken `json:"tokens"`
+	Nickname     string     `json:"nickname,omitempty"`
+	internal     string
+}
+
+func TestMaskedJSONOmitsMaskedFields(t *testing.T) {
+	acc := account{
+		User:         model.User{Name: "Viraj", Email: "viraj@example.com", Age: 30},
+		PasswordHash: "$2a$10$abcdefghijklmnopqrstuv",
+		Tokens:       []apiToken{{Name: "ci", Secret: "s3cr3t"}},
+		internal:     "not exported",
+	}
+	acc.ID = 7
+	acc.CreatedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	r := gin.New()
+	r.GET("/account", func(c *gin.Context) { handler.MaskedJSON(c, http.StatusOK, acc) })
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/account", nil))
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+
+	for _, key := range []string{"password_hash", "nickname", "internal"} {
+		if _, ok := body[key]; ok {
+			t.Errorf("Expected %q to be absent, got %v", key, body[key])
+		}
+	}
+	tokens, _ := body["tokens"].([]interface{})
+	if len(tokens) != 1 {
+		t.Fatalf("Expected 1 token, got %v", body["tokens"])
+	}
+	if _, ok := tokens[0].(map[string]interface{})["secret"]; ok {
+		t.Error("Expected nested secret to be absent")
+	}
+
+	// Non-masked fields keep their encoding/json shape, including promoted gorm.Model fields
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{"name", "Viraj"},
+		{"email", "viraj@example.com"},
+		{"ID", float64(7)},
+		{"CreatedAt", "2024-01-02T03:04:05Z"},
+		{"DeletedAt", nil},
+	}
+	for _, tt := range tests {
+		if got, ok := body[tt.key]; !ok || got != tt.want {
+			t.Errorf("Expected %s=%v, got %v (present=%v)", tt.key, tt.want, got, ok)
+		}
+	}
+}
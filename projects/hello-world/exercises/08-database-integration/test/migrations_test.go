@@ -0,0 +1,117 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+)
+
+func TestSchemaVersionRecordedByAutoMigrate(t *testing.T) {
+	newTestDB(t)
+
+	version, err := database.CurrentVersion()
+	if err != nil {
+		t.Fatalf("CurrentVersion failed: %v", err)
+	}
+	if version != database.SchemaVersion {
+		t.Errorf("Expected %s, got %s", database.SchemaVersion, version)
+	}
+}
+
+func TestRecordMigrationLatestWins(t *testing.T) {
+	db := newTestDB(t)
+
+	versions := []string{"9002_add_tags", "9003_add_comments", "9002_add_tags"}
+	for _, v := range versions {
+		if err := database.RecordMigration(v); err != nil {
+			t.Fatalf("RecordMigration(%s) failed: %v", v, err)
+		}
+	}
+
+	version, err := database.CurrentVersion()
+	if err != nil {
+		t.Fatalf("CurrentVersion failed: %v", err)
+	}
+	if version != "9003_add_comments" {
+		t.Errorf("Expected 9003_add_comments, got %s", version)
+	}
+
+	var count int64
+	db.Model(&database.SchemaMigration{}).Count(&count)
+	if count != 3 { // initial + two distinct versions
+		t.Errorf("Expected 3 recorded versions, got %d", count)
+	}
+}
+
+func TestCurrentVersionEmpty(t *testing.T) {
+	db := newTestDB(t)
+	db.Where("1 = 1").Delete(&database.SchemaMigration{})
+
+	version, err := database.CurrentVersion()
+	if err != nil {
+		t.Fatalf("CurrentVersion failed: %v", err)
+	}
+	if version != "" {
+		t.Errorf("Expected empty version, got %s", version)
+	}
+}
+
+// auditEntry stands in for a model owned by another package
+type auditEntry struct {
+	ID     uint
+	Action string
+}
+
+func TestRegisterModelIsMigrated(t *testing.T) {
+	database.RegisterModel(&auditEntry{})
+	database.RegisterModel(&auditEntry{}) // Duplicate registrations are ignored
+	t.Cleanup(func() { database.UnregisterModel(&auditEntry{}) })
+
+	db := newTestDB(t)
+
+	if !db.Migrator().HasTable(&auditEntry{}) {
+		t.Fatal("Expected the registered model's table to be created")
+	}
+	for _, builtin := range []interface{}{&model.User{}, &model.Enrollment{}, &model.Comment{}} {
+		if !db.Migrator().HasTable(builtin) {
+			t.Errorf("Expected built-in table for %T", builtin)
+		}
+	}
+	if err := db.Create(&auditEntry{Action: "login"}).Error; err != nil {
+		t.Errorf("Expected to insert into the registered table: %v", err)
+	}
+}
+
+func TestUnregisterModelIsNotMigrated(t *testing.T) {
+	database.RegisterModel(&auditEntry{})
+	database.UnregisterModel(&auditEntry{})
+
+	db := newTestDB(t)
+
+	if db.Migrator().HasTable(&auditEntry{}) {
+		t.Error("Expected no table for an unregistered model")
+	}
+	if !db.Migrator().HasTable(&model.User{}) {
+		t.Error("Expected built-in tables to stay registered")
+	}
+}
+
+func TestAutoMigrateDropsGlobalEmailIndex(t *testing.T) {
+	db := newTestDB(t)
+
+	// A pre-0008 database still has UNIQUE (email)
+	if err := db.Exec("CREATE UNIQUE INDEX idx_users_email ON users (email)").Error; err != nil {
+		t.Fatalf("Failed to create legacy index: %v", err)
+	}
+	if err := database.AutoMigrate(); err != nil {
+		t.Fatalf("AutoMigrate failed: %v", err)
+	}
+
+	if db.Migrator().HasIndex(&model.User{}, "idx_users_email") {
+		t.Error("Expected idx_users_email to be dropped")
+	}
+	if !db.Migrator().HasIndex(&model.User{}, "idx_users_tenant_email") {
+		t.Error("Expected idx_users_tenant_email to exist")
+	}
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+)
+
+func TestPoolMetricsExposesGauges(t *testing.T) {
+	db := newTestDB(t)
+	reg := prometheus.NewRegistry()
+
+	metrics, err := database.NewPoolMetrics(db, reg, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to register pool metrics: %v", err)
+	}
+	metrics.Start()
+	defer metrics.Stop()
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	values := map[string]float64{}
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			if g := m.GetGauge(); g != nil {
+				values[mf.GetName()] = g.GetValue()
+			}
+		}
+	}
+
+	// newTestDB ran migrations, so the pool holds at least one connection
+	if got, ok := values["db_open_connections"]; !ok || got < 1 {
+		t.Errorf("Expected db_open_connections >= 1, got %v (present=%v)", got, ok)
+	}
+	if _, ok := values["db_in_use_connections"]; !ok {
+		t.Error("Expected db_in_use_connections gauge to be exposed")
+	}
+}
+
+func TestPoolMetricsStopIsIdempotent(t *testing.T) {
+	metrics, err := database.NewPoolMetrics(newTestDB(t), prometheus.NewRegistry(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to register pool metrics: %v", err)
+	}
+	metrics.Start()
+	metrics.Stop()
+	metrics.Stop() // Second call must not panic or block
+}
+
+func TestPoolMetricsStopWithoutStart(t *testing.T) {
+	metrics, err := database.NewPoolMetrics(newTestDB(t), prometheus.NewRegistry(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to register pool metrics: %v", err)
+	}
+
+	// e.g. startup failed after NewPoolMetrics and shutdown runs anyway
+	stopped := make(chan struct{})
+	go func() {
+		metrics.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Stop without Start to return, it blocked")
+	}
+}
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/pretty"
+)
+
+func TestSeparator(t *testing.T) {
+	tests := []struct {
+		char byte
+		n    int
+		want string
+	}{
+		{'-', 5, "-----"},
+		{'=', 3, "==="},
+		{'-', 0, ""},
+		{'-', -1, ""},
+	}
+
+	for _, tt := range tests {
+		if got := pretty.Separator(tt.char, tt.n); got != tt.want {
+			t.Errorf("Separator(%q, %d): Expected %q, got %q", tt.char, tt.n, tt.want, got)
+		}
+	}
+}
+
+func TestSection(t *testing.T) {
+	want := "\n📌 JOINS\n" + pretty.Separator('-', pretty.SectionWidth)
+	if got := pretty.Section("JOINS"); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
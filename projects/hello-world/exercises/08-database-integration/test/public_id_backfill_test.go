@@ -0,0 +1,109 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// legacyUser is the users table as it was before public_id existed
+type legacyUser struct {
+	gorm.Model
+	Name  string `gorm:"size:100;not null"`
+	Email string `gorm:"size:100;uniqueIndex;not null"`
+	Age   int    `gorm:"default:0"`
+}
+
+func (legacyUser) TableName() string {
+	return "users"
+}
+
+func TestAutoMigrateBackfillsPublicIDs(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "legacy.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	// An app.db from before public_id: two live users and one soft-deleted
+	if err := db.AutoMigrate(&legacyUser{}); err != nil {
+		t.Fatalf("Failed to create legacy schema: %v", err)
+	}
+	legacy := []legacyUser{{Name: "Old 1", Email: "old1@example.com"}, {Name: "Old 2", Email: "old2@example.com"}, {Name: "Gone", Email: "gone@example.com"}}
+	if err := db.Create(&legacy).Error; err != nil {
+		t.Fatalf("Failed to seed legacy users: %v", err)
+	}
+	db.Delete(&legacy[2])
+
+	database.DB = db
+	if err := database.AutoMigrate(); err != nil {
+		t.Fatalf("AutoMigrate failed: %v", err)
+	}
+
+	var notNull bool
+	db.Raw(`SELECT "notnull" FROM pragma_table_info('users') WHERE name = 'public_id'`).Scan(&notNull)
+	if !notNull {
+		t.Error("Expected users.public_id to be NOT NULL after the backfill")
+	}
+
+	var users []model.User
+	db.Unscoped().Order("id").Find(&users)
+	seen := map[string]bool{}
+	for _, u := range users {
+		if _, err := uuid.Parse(u.PublicID); err != nil {
+			t.Errorf("Expected a UUID public id for %s, got %q", u.Email, u.PublicID)
+		}
+		if seen[u.PublicID] {
+			t.Errorf("Expected unique public ids, %q repeated", u.PublicID)
+		}
+		seen[u.PublicID] = true
+	}
+
+	repo := repository.NewUserRepository(db)
+	found, err := repo.FindByPublicID(users[0].PublicID)
+	if err != nil || found == nil || found.ID != users[0].ID {
+		t.Errorf("Expected FindByPublicID to find user %d, got %+v (err %v)", users[0].ID, found, err)
+	}
+
+	// Saving both legacy users used to write "" twice into the unique index
+	for i := range users[:2] {
+		users[i].Age = 40
+		if err := repo.Update(&users[i]); err != nil {
+			t.Errorf("Update of %s failed: %v", users[i].Email, err)
+		}
+	}
+
+	// Running it again leaves the ids alone
+	if err := database.AutoMigrate(); err != nil {
+		t.Fatalf("Second AutoMigrate failed: %v", err)
+	}
+	if again, _ := repo.FindByID(users[0].ID); again == nil || again.PublicID != users[0].PublicID {
+		t.Errorf("Expected public id %s kept, got %+v", users[0].PublicID, again)
+	}
+}
+
+func TestSaveAssignsMissingPublicID(t *testing.T) {
+	db := newTestDB(t)
+	user := seedUser(t, db, "blank@example.com")
+
+	user.PublicID = ""
+	if err := db.Save(user).Error; err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := uuid.Parse(user.PublicID); err != nil {
+		t.Errorf("Expected Save to assign a UUID, got %q", user.PublicID)
+	}
+}
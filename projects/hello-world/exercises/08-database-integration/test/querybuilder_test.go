@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/querybuilder"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"gorm.io/gorm"
+)
+
+// seedAgedUsers creates users "u20".. with the given ages
+func seedAgedUsers(t *testing.T, db *gorm.DB, ages ...int) {
+	t.Helper()
+	for _, age := range ages {
+		u := model.User{Name: fmt.Sprintf("u%d", age), Email: fmt.Sprintf("u%d@example.com", age), Age: age}
+		if err := db.Create(&u).Error; err != nil {
+			t.Fatalf("Failed to seed user: %v", err)
+		}
+	}
+}
+
+func TestQueryBuilderFilters(t *testing.T) {
+	db := newTestDB(t)
+	seedAgedUsers(t, db, 15, 20, 30, 40, 65)
+	repo := repository.NewUserRepository(db)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"equality", "filter[age]=30", []string{"u30"}},
+		{"explicit eq", "filter[name]=eq:u40", []string{"u40"}},
+		{"range", "filter[age]=gte:20&filter[age]=lt:65", []string{"u20", "u30", "u40"}},
+		{"in list", "filter[age]=in:15,65", []string{"u15", "u65"}},
+		{"sorted descending", "filter[age]=gt:30&sort=-age", []string{"u65", "u40"}},
+		{"second page", "sort=age&page=2&page_size=2", []string{"u30", "u40"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			q, err := querybuilder.Parse(values, repository.UserQueryFields)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if q.PageSize == 0 {
+				q.PageSize = 10
+			}
+			users, _, err := repo.FindByQuery(context.Background(), q)
+			if err != nil {
+				t.Fatalf("FindByQuery failed: %v", err)
+			}
+			var got []string
+			for _, u := range users {
+				got = append(got, u.Name)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderRejectsUnknownFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"filter on non-allowlisted field", "filter[tenant_id]=1"},
+		{"sort on non-allowlisted field", "sort=-password"},
+		{"injection attempt", "sort=age%3BDROP%20TABLE%20users"},
+		{"bad page", "page=0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			if _, err := querybuilder.Parse(values, repository.UserQueryFields); !errors.Is(err, querybuilder.ErrInvalidQuery) {
+				t.Errorf("Expected ErrInvalidQuery, got %v", err)
+			}
+		})
+	}
+}
+
+func TestListUsersEndpointFilters(t *testing.T) {
+	db := newTestDB(t)
+	seedAgedUsers(t, db, 15, 20, 30)
+	r := newUserRouter(db)
+
+	w := adminRequest(r, http.MethodGet, "/api/users?filter[age]=gte:18&sort=-age", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var users []model.User
+	if err := decodeData(w.Body.Bytes(), &users); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(users) != 2 || users[0].Name != "u30" {
+		t.Errorf("Expected u30, u20, got %+v", users)
+	}
+
+	if w := adminRequest(r, http.MethodGet, "/api/users?filter[tenant_id]=1", "", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a non-allowlisted field, got %d", w.Code)
+	}
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/querybuilder"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+)
+
+// countingRepo counts list queries reaching the database
+type countingRepo struct {
+	repository.UserRepository
+	findAll   int
+	paginated int
+}
+
+func (r *countingRepo) FindAll(ctx context.Context) ([]model.User, error) {
+	r.findAll++
+	return r.UserRepository.FindAll(ctx)
+}
+
+func (r *countingRepo) FindAllWithPagination(page, pageSize int) ([]model.User, int64, error) {
+	r.paginated++
+	return r.UserRepository.FindAllWithPagination(page, pageSize)
+}
+
+func (r *countingRepo) FindByQuery(ctx context.Context, q *querybuilder.Query) ([]model.User, int64, error) {
+	r.paginated++
+	return r.UserRepository.FindByQuery(ctx, q)
+}
+
+func newCachedUserRouter(t *testing.T) (*gin.Engine, *countingRepo) {
+	t.Helper()
+	db := newTestDB(t)
+	seedUser(t, db, "cached@example.com")
+
+	repo := &countingRepo{UserRepository: repository.NewUserRepository(db)}
+	cache := middleware.NewResponseCache(time.Minute)
+	svc := service.NewUserService(repo, service.WithCacheInvalidation(func() { cache.Invalidate("/api/users") }))
+
+	r := gin.New()
+	handler.NewUserHandler(svc).UseCache(cache).RegisterRoutes(r)
+	return r, repo
+}
+
+func getUsers(t *testing.T, r *gin.Engine, url string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for %s, got %d: %s", url, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func TestResponseCacheServesRepeatedListsOnce(t *testing.T) {
+	r, repo := newCachedUserRouter(t)
+
+	first := getUsers(t, r, "/api/users")
+	second := getUsers(t, r, "/api/users")
+
+	if repo.findAll != 1 {
+		t.Errorf("Expected 1 DB query, got %d", repo.findAll)
+	}
+	if got := second.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("Expected X-Cache HIT, got %q", got)
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Errorf("Expected cached body %s, got %s", first.Body.String(), second.Body.String())
+	}
+}
+
+func TestResponseCacheKeysOnQueryParams(t *testing.T) {
+	r, repo := newCachedUserRouter(t)
+
+	getUsers(t, r, "/api/users?page=1&page_size=10")
+	getUsers(t, r, "/api/users?page_size=10&page=1") // Same params, different order
+	getUsers(t, r, "/api/users?page=2&page_size=10")
+
+	if repo.paginated != 2 {
+		t.Errorf("Expected 2 DB queries (one per distinct page), got %d", repo.paginated)
+	}
+}
+
+func TestResponseCacheInvalidatedOnWrite(t *testing.T) {
+	r, repo := newCachedUserRouter(t)
+
+	getUsers(t, r, "/api/users")
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(`{"name":"New User","email":"new@example.com","age":20}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	after := getUsers(t, r, "/api/users")
+	if repo.findAll != 2 {
+		t.Errorf("Expected write to invalidate cache (2 DB queries), got %d", repo.findAll)
+	}
+	if !strings.Contains(after.Body.String(), "new@example.com") {
+		t.Errorf("Expected new user in list, got %s", after.Body.String())
+	}
+}
+
+func TestResponseCacheSkipsResponseRacingInvalidate(t *testing.T) {
+	cache := middleware.NewResponseCache(time.Minute)
+	r := gin.New()
+	r.Use(cache.Middleware())
+	calls := 0
+	r.GET("/api/users", func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			cache.Invalidate("/api/users") // A write lands after this GET read its data
+		}
+		c.String(http.StatusOK, "users")
+	})
+
+	getUsers(t, r, "/api/users")
+	if cache.Len() != 0 {
+		t.Errorf("Expected stale response not to be cached, got %d entries", cache.Len())
+	}
+
+	getUsers(t, r, "/api/users")
+	if got := getUsers(t, r, "/api/users").Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("Expected later responses to be cached, got X-Cache %q", got)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 handler calls, got %d", calls)
+	}
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/retry"
+)
+
+func TestRetrySucceedsAfterTransientErrors(t *testing.T) {
+	calls := 0
+	err := retry.Do(context.Background(), 5, time.Millisecond, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("database is locked")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	lastErr := errors.New("connection reset by peer")
+	err := retry.Do(context.Background(), 4, time.Millisecond, func() error {
+		calls++
+		return lastErr
+	})
+
+	if calls != 4 {
+		t.Errorf("Expected 4 calls, got %d", calls)
+	}
+	if !errors.Is(err, retry.ErrExhausted) {
+		t.Errorf("Expected ErrExhausted, got %v", err)
+	}
+	if !errors.Is(err, lastErr) {
+		t.Errorf("Expected last error to be wrapped, got %v", err)
+	}
+}
+
+func TestRetryStopsOnNonRetryableError(t *testing.T) {
+	calls := 0
+	err := retry.Do(context.Background(), 5, time.Millisecond, func() error {
+		calls++
+		return errors.New("UNIQUE constraint failed: users.email")
+	})
+
+	if err == nil || calls != 1 {
+		t.Errorf("Expected a single call with an error, got %d calls and %v", calls, err)
+	}
+}
+
+func TestRetryRespectsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retry.Do(ctx, 10, 50*time.Millisecond, func() error {
+		calls++
+		cancel()
+		return errors.New("database is locked")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call before cancellation, got %d", calls)
+	}
+}
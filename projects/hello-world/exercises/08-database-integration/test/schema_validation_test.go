@@ -0,0 +1,112 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+)
+
+// schemaError is the error envelope ValidateJSON answers with
+type schemaError struct {
+	Error struct {
+		Message string                        `json:"message"`
+		Details middleware.SchemaErrorDetails `json:"details"`
+	} `json:"error"`
+}
+
+const nameSchema = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "minLength": 3},
+		"age":  {"type": "integer", "minimum": 0}
+	},
+	"required": ["name"]
+}`
+
+func TestValidateJSON(t *testing.T) {
+	r := gin.New()
+	r.POST("/things", middleware.ValidateJSON(middleware.MustCompileSchema("name.json", nameSchema)), func(c *gin.Context) {
+		// Body must still be readable after validation
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"name": body.Name})
+	})
+
+	tests := []struct {
+		name           string
+		body           string
+		wantStatus     int
+		wantViolations map[string]bool // field -> expected
+	}{
+		{"valid body", `{"name":"Alice","age":30}`, http.StatusOK, nil},
+		{"name too short", `{"name":"Al"}`, http.StatusBadRequest, map[string]bool{"/name": true}},
+		{"short name and negative age", `{"name":"Al","age":-1}`, http.StatusBadRequest, map[string]bool{"/name": true, "/age": true}},
+		{"missing name", `{"age":5}`, http.StatusBadRequest, map[string]bool{"/": true}},
+		{"malformed JSON", `{"name":`, http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+
+			var resp schemaError
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Error.Message == "" {
+				t.Error("Expected error message, got empty")
+			}
+			if len(resp.Error.Details.Violations) != len(tt.wantViolations) {
+				t.Fatalf("Expected %d violations, got %+v", len(tt.wantViolations), resp.Error.Details.Violations)
+			}
+			for _, v := range resp.Error.Details.Violations {
+				if !tt.wantViolations[v.Field] {
+					t.Errorf("Unexpected violation on %q: %s", v.Field, v.Message)
+				}
+				if v.Message == "" {
+					t.Errorf("Expected message for %q, got empty", v.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterRejectsSchemaViolations(t *testing.T) {
+	r := newUserRouter(newTestDB(t))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(`{"name":"A","email":"not-an-email"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp schemaError
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Error.Details.Violations) != 2 {
+		t.Errorf("Expected violations for name and email, got %+v", resp.Error.Details.Violations)
+	}
+}
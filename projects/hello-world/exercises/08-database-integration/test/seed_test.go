@@ -0,0 +1,166 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/seed"
+)
+
+const smallFixture = `
+users:
+  - name: Ann
+    email: ann@example.com
+    age: 30
+    profile: { bio: Tester }
+    posts:
+      - { title: Hello }
+tags:
+  - { name: Go, color: "#00ADD8" }
+authors:
+  - name: Rob Pike
+    books:
+      - { title: Go Book, isbn: "111", price: 10, tags: [Go] }
+students:
+  - { name: Sam, student_code: STU900, email: sam@university.edu }
+courses:
+  - { name: Go 101, code: GO101, credits: 3 }
+enrollments:
+  - { student: STU900, course: GO101, grade: A, completed: true }
+categories:
+  - name: Tech
+    children:
+      - { name: Languages }
+`
+
+// writeFixture saves content under name in a temp dir and returns its path
+func writeFixture(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestLoadFromFileInsertsFixture(t *testing.T) {
+	db := newTestDB(t)
+
+	if err := seed.LoadFromFile(db, writeFixture(t, "seed.yaml", smallFixture)); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+
+	tests := []struct {
+		model interface{}
+		want  int64
+	}{
+		{&model.User{}, 1},
+		{&model.Profile{}, 1},
+		{&model.Post{}, 1},
+		{&model.Tag{}, 1},
+		{&model.Author{}, 1},
+		{&model.Book{}, 1},
+		{&model.Student{}, 1},
+		{&model.Course{}, 1},
+		{&model.Enrollment{}, 1},
+		{&model.Category{}, 2},
+	}
+	for _, tt := range tests {
+		var count int64
+		db.Model(tt.model).Count(&count)
+		if count != tt.want {
+			t.Errorf("Expected %d rows of %T, got %d", tt.want, tt.model, count)
+		}
+	}
+
+	var book model.Book
+	db.Preload("Tags").Where("isbn = ?", "111").First(&book)
+	if len(book.Tags) != 1 || book.Tags[0].Name != "Go" {
+		t.Errorf("Expected book tagged Go, got %v", book.Tags)
+	}
+	var child model.Category
+	db.Where("name = ?", "Languages").First(&child)
+	if child.ParentID == nil {
+		t.Error("Expected child category to have a parent")
+	}
+}
+
+func TestLoadFromFileSupportsJSON(t *testing.T) {
+	db := newTestDB(t)
+	fixture := `{"users": [{"name": "Ann", "email": "ann@example.com"}]}`
+
+	if err := seed.LoadFromFile(db, writeFixture(t, "seed.json", fixture)); err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	var count int64
+	db.Model(&model.User{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected 1 user, got %d", count)
+	}
+}
+
+func TestLoadFromFileReportsInvalidRecords(t *testing.T) {
+	db := newTestDB(t)
+	fixture := `
+users:
+  - { name: Ann, email: ann@example.com }
+  - { name: "", email: not-an-email }
+enrollments:
+  - { student: STU404, course: CS101, grade: Z }
+`
+
+	err := seed.LoadFromFile(db, writeFixture(t, "seed.yaml", fixture))
+	if err == nil {
+		t.Fatal("Expected validation error, got nil")
+	}
+	for _, want := range []string{"users[1].name", "users[1].email", "enrollments[0].student", "enrollments[0].course", "enrollments[0].grade"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %s, got %v", want, err)
+		}
+	}
+
+	var count int64
+	db.Model(&model.User{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected nothing inserted for an invalid fixture, got %d users", count)
+	}
+}
+
+func TestLoadFromFileRollsBackOnInsertFailure(t *testing.T) {
+	db := newTestDB(t)
+	fixture := `
+users:
+  - { name: Ann, email: ann@example.com }
+  - { name: Ann Again, email: ann@example.com }
+`
+
+	err := seed.LoadFromFile(db, writeFixture(t, "seed.yaml", fixture))
+	if err == nil || !strings.Contains(err.Error(), "users[1]") {
+		t.Fatalf("Expected error naming users[1], got %v", err)
+	}
+	var count int64
+	db.Model(&model.User{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected rollback to leave 0 users, got %d", count)
+	}
+}
+
+func TestSampleDataFixtureIsValid(t *testing.T) {
+	db := newTestDB(t)
+	fixture, err := seed.Sample()
+	if err != nil {
+		t.Fatalf("Expected bundled seed data to parse, got %v", err)
+	}
+	if err := seed.Load(db, fixture); err != nil {
+		t.Fatalf("Expected bundled seed data to load, got %v", err)
+	}
+
+	var count int64
+	db.Model(&model.User{}).Count(&count)
+	if count == 0 {
+		t.Error("Expected bundled seed data to insert users")
+	}
+}
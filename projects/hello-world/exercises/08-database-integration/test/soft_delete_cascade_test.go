@@ -0,0 +1,73 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"gorm.io/gorm"
+)
+
+// seedUserWithContent creates a user with a profile and two posts
+func seedUserWithContent(t *testing.T, db *gorm.DB, email string) *model.User {
+	t.Helper()
+	user := &model.User{
+		Name:    email,
+		Email:   email,
+		Profile: model.Profile{Bio: "bio"},
+		Posts:   []model.Post{{Title: "first"}, {Title: "second"}},
+	}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("Failed to seed user %s: %v", email, err)
+	}
+	return user
+}
+
+// liveChildren counts the user's profile and posts that aren't soft-deleted
+func liveChildren(db *gorm.DB, userID uint) (profiles, posts int64) {
+	db.Model(&model.Profile{}).Where("user_id = ?", userID).Count(&profiles)
+	db.Model(&model.Post{}).Where("user_id = ?", userID).Count(&posts)
+	return profiles, posts
+}
+
+func TestSoftDeleteCascadesToProfileAndPosts(t *testing.T) {
+	tests := []struct {
+		name   string
+		delete func(db *gorm.DB, user *model.User) error
+	}{
+		{"delete by value", func(db *gorm.DB, user *model.User) error {
+			return db.Delete(user).Error
+		}},
+		{"repository delete by id", func(db *gorm.DB, user *model.User) error {
+			return repository.NewUserRepository(db).Delete(user.ID)
+		}},
+		{"repository bulk delete", func(db *gorm.DB, user *model.User) error {
+			_, err := repository.NewUserRepository(db).DeleteMany([]uint{user.ID})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			doomed := seedUserWithContent(t, db, "doomed@example.com")
+			bystander := seedUserWithContent(t, db, "bystander@example.com")
+
+			if err := tt.delete(db, doomed); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+
+			if profiles, posts := liveChildren(db, doomed.ID); profiles != 0 || posts != 0 {
+				t.Errorf("Expected profile and posts soft-deleted, got %d live profiles and %d live posts", profiles, posts)
+			}
+			var kept int64
+			db.Unscoped().Model(&model.Post{}).Where("user_id = ?", doomed.ID).Count(&kept)
+			if kept != 2 {
+				t.Errorf("Expected 2 soft-deleted posts still in the table, got %d", kept)
+			}
+			if profiles, posts := liveChildren(db, bystander.ID); profiles != 1 || posts != 2 {
+				t.Errorf("Expected other user's content untouched, got %d profiles and %d posts", profiles, posts)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+)
+
+func TestAuthorCountByTag(t *testing.T) {
+	db := newTestDB(t)
+
+	golang := model.Tag{Name: "go"}
+	java := model.Tag{Name: "java"}
+	unused := model.Tag{Name: "rust"}
+	for _, tag := range []*model.Tag{&golang, &java, &unused} {
+		if err := db.Create(tag).Error; err != nil {
+			t.Fatalf("Failed to create tag: %v", err)
+		}
+	}
+
+	// Alice has two "go" books: she must be counted once
+	authors := []model.Author{
+		{Name: "Alice", Books: []model.Book{
+			{Title: "Go 101", ISBN: "a1", Tags: []model.Tag{golang}},
+			{Title: "Go 102", ISBN: "a2", Tags: []model.Tag{golang, java}},
+		}},
+		{Name: "Bob", Books: []model.Book{
+			{Title: "Go for Java devs", ISBN: "b1", Tags: []model.Tag{golang, java}},
+		}},
+		{Name: "Carol", Books: []model.Book{
+			{Title: "Deleted Java book", ISBN: "c1", Tags: []model.Tag{java}},
+		}},
+	}
+	for i := range authors {
+		if err := db.Create(&authors[i]).Error; err != nil {
+			t.Fatalf("Failed to create author: %v", err)
+		}
+	}
+	db.Delete(&authors[2].Books[0]) // soft-deleted books don't count
+
+	counts, err := repository.NewTagRepository(db).AuthorCountByTag()
+	if err != nil {
+		t.Fatalf("AuthorCountByTag failed: %v", err)
+	}
+
+	expected := map[string]int64{"go": 2, "java": 2, "rust": 0}
+	for tag, want := range expected {
+		if got, ok := counts[tag]; !ok || got != want {
+			t.Errorf("Tag %s: expected %d authors, got %d (present=%v)", tag, want, got, ok)
+		}
+	}
+	if len(counts) != len(expected) {
+		t.Errorf("Expected %d tags, got %d: %v", len(expected), len(counts), counts)
+	}
+}
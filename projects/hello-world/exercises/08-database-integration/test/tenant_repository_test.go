@@ -0,0 +1,155 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"gorm.io/gorm"
+)
+
+// tenantRepo returns a repository scoped to tenantID
+func tenantRepo(t *testing.T, db *gorm.DB, tenantID uint) repository.UserRepository {
+	t.Helper()
+	repo, err := repository.NewTenantUserRepository(repository.WithTenant(context.Background(), tenantID), db)
+	if err != nil {
+		t.Fatalf("NewTenantUserRepository failed: %v", err)
+	}
+	return repo
+}
+
+func TestTenantRepositoryRequiresTenant(t *testing.T) {
+	db := newTestDB(t)
+	if _, err := repository.NewTenantUserRepository(context.Background(), db); !errors.Is(err, repository.ErrNoTenant) {
+		t.Errorf("Expected ErrNoTenant, got %v", err)
+	}
+}
+
+func TestTenantRepositoryIsolatesTenants(t *testing.T) {
+	db := newTestDB(t)
+	repoA, repoB := tenantRepo(t, db, 1), tenantRepo(t, db, 2)
+
+	alice := &model.User{Name: "Alice", Email: "alice@a.com", Age: 30}
+	if err := repoA.Create(alice); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	bob := &model.User{Name: "Bob", Email: "bob@b.com", Age: 40}
+	if err := repoB.Create(bob); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if alice.TenantID != 1 || bob.TenantID != 2 {
+		t.Fatalf("Expected tenants stamped on create, got %d and %d", alice.TenantID, bob.TenantID)
+	}
+
+	all, err := repoA.FindAll(context.Background())
+	if err != nil || len(all) != 1 || all[0].ID != alice.ID {
+		t.Errorf("Expected FindAll to return only Alice, got %v (err %v)", all, err)
+	}
+	page, total, err := repoA.FindAllWithPagination(1, 10)
+	if err != nil || total != 1 || len(page) != 1 {
+		t.Errorf("Expected 1 user on tenant A's page, got %d of %d (err %v)", len(page), total, err)
+	}
+
+	lookups := []struct {
+		name string
+		find func() (*model.User, error)
+	}{
+		{"FindByID", func() (*model.User, error) { return repoA.FindByID(bob.ID) }},
+		{"FindByIDWithProfile", func() (*model.User, error) { return repoA.FindByIDWithProfile(bob.ID) }},
+		{"FindByIDWithPosts", func() (*model.User, error) { return repoA.FindByIDWithPosts(bob.ID) }},
+		{"FindByEmail", func() (*model.User, error) { return repoA.FindByEmail(bob.Email) }},
+	}
+	for _, tt := range lookups {
+		user, err := tt.find()
+		if err != nil || user != nil {
+			t.Errorf("%s: Expected tenant B's user to be invisible, got %v (err %v)", tt.name, user, err)
+		}
+	}
+
+	if users, _ := repoA.FindByAgeGreaterThan(0); len(users) != 1 {
+		t.Errorf("Expected 1 adult in tenant A, got %d", len(users))
+	}
+	if users, _ := repoA.FindByNameContaining(context.Background(), "Bob"); len(users) != 0 {
+		t.Errorf("Expected name search not to cross tenants, got %d", len(users))
+	}
+	if exists, _ := repoA.ExistsByEmail(bob.Email); exists {
+		t.Error("Expected tenant B's email to be unknown to tenant A")
+	}
+	if users, _ := repoA.FindWithoutPosts(); len(users) != 1 {
+		t.Errorf("Expected 1 user without posts in tenant A, got %d", len(users))
+	}
+}
+
+func TestTenantRepositoryRejectsCrossTenantWrites(t *testing.T) {
+	db := newTestDB(t)
+	repoA, repoB := tenantRepo(t, db, 1), tenantRepo(t, db, 2)
+
+	bob := &model.User{Name: "Bob", Email: "bob@b.com"}
+	if err := repoB.Create(bob); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	hijacked := *bob
+	hijacked.Name = "Hijacked"
+	if err := repoA.Update(&hijacked); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound updating another tenant's user, got %v", err)
+	}
+	// Emails are unique per tenant: this creates tenant A's own bob@b.com and leaves Bob alone
+	own := &model.User{Name: "Hijacked", Email: "bob@b.com"}
+	if err := repoA.UpsertByEmail(own); err != nil {
+		t.Fatalf("UpsertByEmail failed: %v", err)
+	}
+	if own.ID == bob.ID || own.TenantID != 1 {
+		t.Errorf("Expected a new tenant A user, got id %d tenant %d", own.ID, own.TenantID)
+	}
+	if err := repoA.Delete(bob.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := repoA.HardDelete(bob.ID); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound hard-deleting another tenant's user, got %v", err)
+	}
+
+	got, err := repoB.FindByID(bob.ID)
+	if err != nil || got == nil {
+		t.Fatalf("Expected Bob to survive tenant A's writes, got %v (err %v)", got, err)
+	}
+	if got.Name != "Bob" {
+		t.Errorf("Expected name Bob, got %s", got.Name)
+	}
+}
+
+func TestSameEmailInTwoTenants(t *testing.T) {
+	db := newTestDB(t)
+	svcA := service.NewUserService(tenantRepo(t, db, 1))
+	svcB := service.NewUserService(tenantRepo(t, db, 2))
+
+	a, err := svcA.Register("Sam A", "sam@example.com", 30)
+	if err != nil {
+		t.Fatalf("Register in tenant A failed: %v", err)
+	}
+	b, err := svcB.Register("Sam B", "sam@example.com", 31)
+	if err != nil {
+		t.Fatalf("Expected tenant B to register the same email, got %v", err)
+	}
+	if a.ID == b.ID {
+		t.Errorf("Expected two users, got id %d twice", a.ID)
+	}
+
+	if _, err := svcB.Register("Sam B again", "sam@example.com", 32); !errors.Is(err, service.ErrConflict) {
+		t.Errorf("Expected ErrConflict within one tenant, got %v", err)
+	}
+
+	upserted := &model.User{Name: "Sam B v2", Email: "sam@example.com"}
+	if err := tenantRepo(t, db, 2).UpsertByEmail(upserted); err != nil {
+		t.Fatalf("UpsertByEmail failed: %v", err)
+	}
+	if upserted.ID != b.ID {
+		t.Errorf("Expected upsert to update tenant B's user %d, got %d", b.ID, upserted.ID)
+	}
+	if found, _ := tenantRepo(t, db, 1).FindByID(a.ID); found == nil || found.Name != "Sam A" {
+		t.Errorf("Expected tenant A's user untouched, got %+v", found)
+	}
+}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTxRouter registers a handler that inserts a user through the request tx and replies with status
+func newTxRouter(db *gorm.DB, status int) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery(), middleware.Transaction(db))
+	r.POST("/users", func(c *gin.Context) {
+		tx := middleware.GetTx(c)
+		tx.Create(&model.User{Name: "Tx User", Email: "tx@example.com"})
+		if status == 0 {
+			panic("handler blew up")
+		}
+		c.JSON(status, gin.H{})
+	})
+	return r
+}
+
+func countUsers(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var count int64
+	if err := db.Model(&model.User{}).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count users: %v", err)
+	}
+	return count
+}
+
+func TestTransactionCommitsOn2xx(t *testing.T) {
+	db := newTestDB(t)
+
+	w := httptest.NewRecorder()
+	newTxRouter(db, http.StatusCreated).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", w.Code)
+	}
+	if got := countUsers(t, db); got != 1 {
+		t.Errorf("Expected committed user, got %d rows", got)
+	}
+}
+
+func TestTransactionRollsBackOn4xx(t *testing.T) {
+	db := newTestDB(t)
+
+	w := httptest.NewRecorder()
+	newTxRouter(db, http.StatusBadRequest).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	if got := countUsers(t, db); got != 0 {
+		t.Errorf("Expected rollback, got %d rows", got)
+	}
+}
+
+func TestTransactionRollsBackOnPanic(t *testing.T) {
+	db := newTestDB(t)
+
+	w := httptest.NewRecorder()
+	newTxRouter(db, 0).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 from recovery, got %d", w.Code)
+	}
+	if got := countUsers(t, db); got != 0 {
+		t.Errorf("Expected rollback after panic, got %d rows", got)
+	}
+}
+
+func TestTransactionReturns500WhenCommitFails(t *testing.T) {
+	// Foreign keys on, so a deferred constraint can fail at COMMIT
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "fk.db")+"?_foreign_keys=on"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	database.DB = db
+	if err := database.AutoMigrate(); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	r := gin.New()
+	r.Use(middleware.Transaction(db))
+	r.POST("/posts", func(c *gin.Context) {
+		tx := middleware.GetTx(c)
+		tx.Exec("PRAGMA defer_foreign_keys = ON")
+		if err := tx.Create(&model.Post{Title: "Orphan", UserID: 9999}).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"title": "Orphan"})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/posts", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500 for a failed commit, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "Orphan") {
+		t.Errorf("Expected the handler's body to be discarded, got %s", w.Body.String())
+	}
+	var count int64
+	db.Model(&model.Post{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected the post to be rolled back, got %d rows", count)
+	}
+}
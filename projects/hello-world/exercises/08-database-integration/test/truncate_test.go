@@ -0,0 +1,90 @@
+package test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestTruncateAll(t *testing.T) {
+	db := newTestDB(t)
+
+	user := model.User{Name: "Alice", Email: "alice@example.com", Posts: []model.Post{{Title: "Hello"}}}
+	author := model.Author{Name: "Orwell", Books: []model.Book{{Title: "1984", ISBN: "isbn-1984", Tags: []model.Tag{{Name: "classic"}}}}}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("Failed to seed user: %v", err)
+	}
+	if err := db.Create(&author).Error; err != nil {
+		t.Fatalf("Failed to seed author: %v", err)
+	}
+	db.Delete(&user) // soft-deleted rows must go too
+
+	if err := database.TruncateAll(db); err != nil {
+		t.Fatalf("TruncateAll failed: %v", err)
+	}
+
+	tables := []string{"users", "profiles", "posts", "authors", "books", "tags", "book_tags"}
+	for _, table := range tables {
+		var count int64
+		if err := db.Table(table).Count(&count).Error; err != nil {
+			t.Fatalf("Failed to count %s: %v", table, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected %s to be empty, got %d rows", table, count)
+		}
+	}
+}
+
+// openFileDB opens and migrates a SQLite file with the given base name
+func openFileDB(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	database.DB = db
+	if err := database.AutoMigrate(); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	return db
+}
+
+func TestTruncateAllRefusesNonTestDatabase(t *testing.T) {
+	for _, name := range []string{"app.db", "latest.db", "contest.db", "test_data.db"} {
+		t.Run(name, func(t *testing.T) {
+			db := openFileDB(t, name)
+
+			if err := database.TruncateAll(db); !errors.Is(err, database.ErrNotTestDatabase) {
+				t.Errorf("Expected ErrNotTestDatabase, got %v", err)
+			}
+
+			t.Setenv("ALLOW_TRUNCATE", "true")
+			if err := database.TruncateAll(db); err != nil {
+				t.Errorf("Expected forced truncate to succeed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTruncateAllAcceptsTestDatabaseNames(t *testing.T) {
+	for _, name := range []string{"test.db", "app_test.db", "app.test.db"} {
+		t.Run(name, func(t *testing.T) {
+			if err := database.TruncateAll(openFileDB(t, name)); err != nil {
+				t.Errorf("Expected truncate to succeed, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"gorm.io/gorm"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	db := newTestDB(t)
+	seedUser(t, db, "taken@example.com")
+	sqliteErr := db.Create(&model.User{Name: "Dup", Email: "taken@example.com"}).Error
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"sqlite", sqliteErr, true},
+		{"postgres", errors.New(`ERROR: duplicate key value violates unique constraint "idx_users_email" (SQLSTATE 23505)`), true},
+		{"mysql", errors.New("Error 1062 (23000): Duplicate entry 'taken@example.com' for key 'users.idx_users_email'"), true},
+		{"gorm translated", fmt.Errorf("create: %w", gorm.ErrDuplicatedKey), true},
+		{"not null", errors.New("NOT NULL constraint failed: users.name"), false},
+		{"not found", gorm.ErrRecordNotFound, false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := database.IsUniqueViolation(tt.err); got != tt.want {
+				t.Errorf("Expected %v for %v, got %v", tt.want, tt.err, got)
+			}
+		})
+	}
+}
+
+// duplicateRepo fails every Create the way Postgres reports a taken email
+type duplicateRepo struct {
+	repository.UserRepository
+}
+
+func (duplicateRepo) ExistsByEmail(string) (bool, error) { return false, nil }
+
+func (duplicateRepo) Create(*model.User) error {
+	return errors.New(`ERROR: duplicate key value violates unique constraint "idx_users_email" (SQLSTATE 23505)`)
+}
+
+func TestRegisterReturnsConflict(t *testing.T) {
+	t.Run("email already registered", func(t *testing.T) {
+		db := newTestDB(t)
+		seedUser(t, db, "taken@example.com")
+		svc := service.NewUserService(repository.NewUserRepository(db))
+
+		if _, err := svc.Register("Dup", "taken@example.com", 30); !errors.Is(err, service.ErrConflict) {
+			t.Errorf("Expected ErrConflict, got %v", err)
+		}
+	})
+
+	t.Run("email held by soft-deleted user", func(t *testing.T) {
+		db := newTestDB(t)
+		gone := seedUser(t, db, "gone@example.com")
+		db.Delete(gone)
+		svc := service.NewUserService(repository.NewUserRepository(db))
+
+		if _, err := svc.Register("Back", "gone@example.com", 30); !errors.Is(err, service.ErrConflict) {
+			t.Errorf("Expected ErrConflict from the unique index, got %v", err)
+		}
+	})
+
+	t.Run("driver error from a lost race", func(t *testing.T) {
+		svc := service.NewUserService(duplicateRepo{})
+
+		_, err := svc.Register("Racer", "race@example.com", 30)
+		if !errors.Is(err, service.ErrConflict) {
+			t.Fatalf("Expected ErrConflict, got %v", err)
+		}
+		if strings.Contains(err.Error(), "SQLSTATE") {
+			t.Errorf("Expected driver details hidden, got %q", err.Error())
+		}
+	})
+}
+
+func TestRegisterDuplicateEmailReturns409(t *testing.T) {
+	db := newTestDB(t)
+	seedUser(t, db, "taken@example.com")
+	r := newUserRouter(db)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(`{"name":"Dup","email":"taken@example.com","age":30}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,180 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"gorm.io/gorm"
+)
+
+// newUserRouter wires the real repository/service/handler stack on db
+func newUserRouter(db *gorm.DB) *gin.Engine {
+	r := gin.New()
+	handler.NewUserHandler(service.NewUserService(repository.NewUserRepository(db))).RegisterRoutes(r)
+	return r
+}
+
+func TestUserStats(t *testing.T) {
+	db := newTestDB(t)
+
+	poster := seedUser(t, db, "poster@example.com")
+	lurker := seedUser(t, db, "lurker@example.com")
+	latest := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, created := range []time.Time{latest.Add(-48 * time.Hour), latest} {
+		post := map[string]interface{}{"title": "Post", "user_id": poster.ID, "created_at": created, "updated_at": created}
+		if err := db.Table("posts").Create(post).Error; err != nil {
+			t.Fatalf("Failed to seed post: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		userID     uint
+		wantStatus int
+		wantCount  int64
+		wantLast   *time.Time
+	}{
+		{"user with posts", poster.ID, http.StatusOK, 2, &latest},
+		{"user without posts", lurker.ID, http.StatusOK, 0, nil},
+		{"unknown user", 9999, http.StatusNotFound, 0, nil},
+	}
+
+	r := newUserRouter(db)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/users/%d/stats", tt.userID), nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				PostCount  int64      `json:"post_count"`
+				LastPostAt *time.Time `json:"last_post_at"`
+			}
+			if err := decodeData(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if body.PostCount != tt.wantCount {
+				t.Errorf("Expected post_count %d, got %d", tt.wantCount, body.PostCount)
+			}
+			switch {
+			case tt.wantLast == nil && body.LastPostAt != nil:
+				t.Errorf("Expected null last_post_at, got %v", body.LastPostAt)
+			case tt.wantLast != nil && (body.LastPostAt == nil || !body.LastPostAt.Equal(*tt.wantLast)):
+				t.Errorf("Expected last_post_at %v, got %v", tt.wantLast, body.LastPostAt)
+			}
+		})
+	}
+}
+
+func TestUpdateProfileCreatesMissingProfile(t *testing.T) {
+	db := newTestDB(t)
+	user := seedUser(t, db, "no-profile@example.com") // Created without a profile row
+	r := newUserRouter(db)
+
+	for _, bio := range []string{"first", "second"} {
+		w := adminRequest(r, http.MethodPut, "/api/users/"+itoa(user.ID)+"/profile", "", `{"bio":"`+bio+`"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	var profiles []model.Profile
+	db.Where("user_id = ?", user.ID).Find(&profiles)
+	if len(profiles) != 1 {
+		t.Fatalf("Expected exactly 1 profile, got %d", len(profiles))
+	}
+	if profiles[0].Bio != "second" {
+		t.Errorf("Expected bio second, got %q", profiles[0].Bio)
+	}
+
+	w := adminRequest(r, http.MethodPut, "/api/users/9999/profile", "", `{"bio":"ghost"}`)
+	if w.Code == http.StatusOK {
+		t.Errorf("Expected an error for an unknown user, got 200")
+	}
+	var count int64
+	db.Model(&model.Profile{}).Where("user_id = ?", 9999).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no profile for an unknown user, got %d", count)
+	}
+}
+
+func TestUpdateProfileRevivesSoftDeletedProfile(t *testing.T) {
+	db := newTestDB(t)
+	user := seedUser(t, db, "returning@example.com")
+	old := model.Profile{UserID: user.ID, Bio: "old"}
+	db.Create(&old)
+	db.Delete(&old)
+
+	w := adminRequest(newUserRouter(db), http.MethodPut, "/api/users/"+itoa(user.ID)+"/profile", "", `{"bio":"new"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var profile model.Profile
+	if err := db.Where("user_id = ?", user.ID).First(&profile).Error; err != nil {
+		t.Fatalf("Expected a live profile: %v", err)
+	}
+	if profile.ID != old.ID || profile.Bio != "new" {
+		t.Errorf("Expected profile %d revived with bio new, got %d %q", old.ID, profile.ID, profile.Bio)
+	}
+}
+
+func TestGetUsersByIDs(t *testing.T) {
+	db := newTestDB(t)
+	alice := seedUser(t, db, "alice@example.com")
+	seedUser(t, db, "bob@example.com")
+	carol := seedUser(t, db, "carol@example.com")
+	r := newUserRouter(db)
+
+	// Carol before Alice, plus an id that doesn't exist
+	w := getUsers(t, r, fmt.Sprintf("/api/users?ids=%d,%d,9999", carol.ID, alice.ID))
+
+	var users []model.User
+	if err := decodeData(w.Body.Bytes(), &users); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+	if users[0].Email != "alice@example.com" || users[1].Email != "carol@example.com" {
+		t.Errorf("Expected alice and carol, got %s and %s", users[0].Email, users[1].Email)
+	}
+}
+
+func TestGetUsersByIDsRejectsBadIDs(t *testing.T) {
+	r := newUserRouter(newTestDB(t))
+
+	tests := []struct {
+		name string
+		ids  string
+	}{
+		{"not a number", "1,abc"},
+		{"empty", ""},
+		{"too many", strings.Repeat("1,", 100) + "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/users?ids="+tt.ids, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/model"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+)
+
+// makeUsers builds n users with unique emails
+func makeUsers(n int) []model.User {
+	users := make([]model.User, n)
+	for i := range users {
+		users[i] = model.User{Name: fmt.Sprintf("User %d", i), Email: fmt.Sprintf("user%d@example.com", i)}
+	}
+	return users
+}
+
+func TestCreateManyInBatches(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	if err := repo.CreateMany(makeUsers(1000), 100); err != nil {
+		t.Fatalf("CreateMany failed: %v", err)
+	}
+
+	var count int64
+	db.Model(&model.User{}).Count(&count)
+	if count != 1000 {
+		t.Errorf("Expected 1000 users, got %d", count)
+	}
+}
+
+func TestCreateManyRollsBackOnFailure(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	users := makeUsers(250)
+	users[249].Email = users[0].Email // duplicate in the last batch
+
+	if err := repo.CreateMany(users, 100); err == nil {
+		t.Fatal("Expected unique constraint error, got nil")
+	}
+
+	var count int64
+	db.Model(&model.User{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected rollback to leave 0 users, got %d", count)
+	}
+}
+
+func TestFindWithoutPosts(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	poster := model.User{Name: "Poster", Email: "poster@example.com", Posts: []model.Post{{Title: "Hi"}}}
+	lurker := model.User{Name: "Lurker", Email: "lurker@example.com"}
+	deletedPoster := model.User{Name: "Deleted Poster", Email: "deleted@example.com", Posts: []model.Post{{Title: "Oops"}}}
+	for _, u := range []*model.User{&poster, &lurker, &deletedPoster} {
+		if err := db.Create(u).Error; err != nil {
+			t.Fatalf("Failed to seed user: %v", err)
+		}
+	}
+	db.Delete(&deletedPoster.Posts[0])
+
+	users, err := repo.FindWithoutPosts()
+	if err != nil {
+		t.Fatalf("FindWithoutPosts failed: %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, u := range users {
+		got[u.Email] = true
+	}
+	if len(users) != 2 || !got["lurker@example.com"] || !got["deleted@example.com"] {
+		t.Errorf("Expected lurker and deleted poster, got %v", got)
+	}
+}
+
+func TestFindByNameContainingEscapesWildcards(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	for i, name := range []string{"Ann 100%", "Ann 1000", "a_b", "axb"} {
+		u := model.User{Name: name, Email: fmt.Sprintf("user%d@example.com", i)}
+		if err := db.Create(&u).Error; err != nil {
+			t.Fatalf("Failed to seed user: %v", err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"100%", "Ann 100%"},
+		{"a_b", "a_b"},
+	}
+	for _, tt := range tests {
+		users, err := repo.FindByNameContaining(context.Background(), tt.query)
+		if err != nil {
+			t.Fatalf("FindByNameContaining(%q) failed: %v", tt.query, err)
+		}
+		if len(users) != 1 || users[0].Name != tt.want {
+			t.Errorf("FindByNameContaining(%q): Expected only %q, got %+v", tt.query, tt.want, users)
+		}
+	}
+}
+
+func TestPublicIDAssignedOnCreate(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	single := model.User{Name: "Single", Email: "single@example.com"}
+	if err := repo.Create(&single); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	batch := makeUsers(3)
+	if err := repo.CreateMany(batch, 2); err != nil {
+		t.Fatalf("CreateMany failed: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for _, u := range append([]model.User{single}, batch...) {
+		if _, err := uuid.Parse(u.PublicID); err != nil {
+			t.Errorf("Expected a UUID public id for %s, got %q", u.Email, u.PublicID)
+		}
+		if seen[u.PublicID] {
+			t.Errorf("Expected unique public ids, %q repeated", u.PublicID)
+		}
+		seen[u.PublicID] = true
+	}
+
+	found, err := repo.FindByPublicID(single.PublicID)
+	if err != nil {
+		t.Fatalf("FindByPublicID failed: %v", err)
+	}
+	if found == nil || found.ID != single.ID {
+		t.Errorf("Expected user %d, got %+v", single.ID, found)
+	}
+
+	missing, err := repo.FindByPublicID(uuid.NewString())
+	if err != nil || missing != nil {
+		t.Errorf("Expected nil, nil for an unknown public id, got %+v, %v", missing, err)
+	}
+}
+
+func TestPublicIDKeptWhenProvided(t *testing.T) {
+	db := newTestDB(t)
+	id := "8f14e45f-ceea-4f4b-9a1c-3d7e2b1c0a55"
+	user := model.User{Name: "Imported", Email: "imported@example.com", PublicID: id}
+	if err := repository.NewUserRepository(db).Create(&user); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if user.PublicID != id {
+		t.Errorf("Expected public id %s to be kept, got %s", id, user.PublicID)
+	}
+}
+
+func TestUpsertByEmail(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	first := model.User{Name: "Sync v1", Email: "sync@example.com", Age: 20}
+	if err := repo.UpsertByEmail(&first); err != nil {
+		t.Fatalf("First UpsertByEmail failed: %v", err)
+	}
+
+	second := model.User{Name: "Sync v2", Email: "sync@example.com", Age: 21}
+	if err := repo.UpsertByEmail(&second); err != nil {
+		t.Fatalf("Second UpsertByEmail failed: %v", err)
+	}
+
+	var count int64
+	db.Model(&model.User{}).Where("email = ?", "sync@example.com").Count(&count)
+	if count != 1 {
+		t.Fatalf("Expected 1 row, got %d", count)
+	}
+
+	found, err := repo.FindByEmail("sync@example.com")
+	if err != nil || found == nil {
+		t.Fatalf("FindByEmail failed: %v", err)
+	}
+	if found.Name != "Sync v2" || found.Age != 21 {
+		t.Errorf("Expected latest values Sync v2/21, got %s/%d", found.Name, found.Age)
+	}
+	if second.ID != first.ID || second.PublicID != first.PublicID {
+		t.Errorf("Expected id %d and public id %s kept, got %d and %s", first.ID, first.PublicID, second.ID, second.PublicID)
+	}
+}
+
+func TestUpsertByEmailRevivesDeletedUser(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	gone := seedUser(t, db, "gone@example.com")
+	db.Delete(gone)
+
+	back := model.User{Name: "Back", Email: "gone@example.com"}
+	if err := repo.UpsertByEmail(&back); err != nil {
+		t.Fatalf("UpsertByEmail failed: %v", err)
+	}
+	if back.ID != gone.ID {
+		t.Errorf("Expected revived user %d, got %d", gone.ID, back.ID)
+	}
+	if found, _ := repo.FindByID(gone.ID); found == nil || found.Name != "Back" {
+		t.Errorf("Expected user %d live with name Back, got %+v", gone.ID, found)
+	}
+}
+
+func TestEachUser(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	if err := repo.CreateMany(makeUsers(25), 10); err != nil {
+		t.Fatalf("CreateMany failed: %v", err)
+	}
+	gone := seedUser(t, db, "gone@example.com")
+	db.Delete(gone)
+
+	var emails []string
+	err := repo.EachUser(func(u model.User) error {
+		emails = append(emails, u.Email)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachUser failed: %v", err)
+	}
+	if len(emails) != 25 {
+		t.Fatalf("Expected 25 live users, got %d", len(emails))
+	}
+	if emails[0] != "user0@example.com" || emails[24] != "user24@example.com" {
+		t.Errorf("Expected users in id order, got first %s and last %s", emails[0], emails[24])
+	}
+}
+
+func TestEachUserStopsOnError(t *testing.T) {
+	db := newTestDB(t)
+	repo := repository.NewUserRepository(db)
+
+	if err := repo.CreateMany(makeUsers(10), 10); err != nil {
+		t.Fatalf("CreateMany failed: %v", err)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err := repo.EachUser(func(u model.User) error {
+		calls++
+		if calls == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Expected the callback's error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
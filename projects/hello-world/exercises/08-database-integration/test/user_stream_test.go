@@ -0,0 +1,97 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+)
+
+func TestUserStreamReceivesRegistration(t *testing.T) {
+	db := newTestDB(t)
+	events := service.NewUserBroadcaster()
+	svc := service.NewUserServiceWithEvents(repository.NewUserRepository(db), events)
+
+	r := gin.New()
+	handler.NewUserHandler(svc).RegisterRoutes(r)
+	handler.NewUserStreamHandler(events).RegisterRoutes(r)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/users/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to connect to stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("Expected text/event-stream, got %s", ct)
+	}
+
+	if _, err := svc.Register("Streamer", "stream@example.com", 30); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	var event, data string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() && data == "" {
+		line := scanner.Text()
+		if v, ok := strings.CutPrefix(line, "event:"); ok {
+			event = strings.TrimSpace(v)
+		}
+		if v, ok := strings.CutPrefix(line, "data:"); ok {
+			data = strings.TrimSpace(v)
+		}
+	}
+
+	if event != "user_created" {
+		t.Errorf("Expected event user_created, got %q", event)
+	}
+	var user handler.UserResponse
+	if err := json.Unmarshal([]byte(data), &user); err != nil {
+		t.Fatalf("Invalid event data %q: %v", data, err)
+	}
+	if user.Email != "stream@example.com" || user.ID == 0 {
+		t.Errorf("Unexpected user in event: %+v", user)
+	}
+}
+
+func TestUserStreamUnsubscribesOnDisconnect(t *testing.T) {
+	events := service.NewUserBroadcaster()
+	r := gin.New()
+	handler.NewUserStreamHandler(events).RegisterRoutes(r)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/users/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to connect to stream: %v", err)
+	}
+	if events.Subscribers() != 1 {
+		t.Fatalf("Expected 1 subscriber, got %d", events.Subscribers())
+	}
+
+	cancel()
+	resp.Body.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for events.Subscribers() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := events.Subscribers(); n != 0 {
+		t.Errorf("Expected subscriber to be removed after disconnect, got %d", n)
+	}
+}
@@ -0,0 +1,134 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/database"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/middleware"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/repository"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/08-database-integration/service"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// slowQuery makes SQLite count to a few hundred thousand, taking well over a millisecond
+const slowQuery = `WITH RECURSIVE n(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM n WHERE x < 500000) SELECT count(*) FROM n`
+
+func TestZapLoggerWarnsOnSlowQuery(t *testing.T) {
+	db := newTestDB(t)
+	core, logs := observer.New(zapcore.DebugLevel)
+	zl := database.NewZapLogger(zap.New(core), time.Millisecond)
+	db = db.Session(&gorm.Session{Logger: zl.LogMode(gormlogger.Warn)})
+
+	var count int64
+	if err := db.Raw(slowQuery).Scan(&count).Error; err != nil {
+		t.Fatalf("Slow query failed: %v", err)
+	}
+
+	slow := logs.FilterMessage("slow query").All()
+	if len(slow) != 1 {
+		t.Fatalf("Expected 1 slow query warning, got %d", len(slow))
+	}
+	if slow[0].Level != zapcore.WarnLevel {
+		t.Errorf("Expected warn level, got %s", slow[0].Level)
+	}
+	fields := slow[0].ContextMap()
+	if fields["sql"] != slowQuery {
+		t.Errorf("Expected sql field to hold the query, got %v", fields["sql"])
+	}
+	if d, ok := fields["duration"].(time.Duration); !ok || d <= time.Millisecond {
+		t.Errorf("Expected duration above threshold, got %v", fields["duration"])
+	}
+}
+
+func TestZapLoggerQuietForFastQueries(t *testing.T) {
+	db := newTestDB(t)
+	core, logs := observer.New(zapcore.DebugLevel)
+	zl := database.NewZapLogger(zap.New(core), time.Hour)
+	db = db.Session(&gorm.Session{Logger: zl.LogMode(gormlogger.Warn)})
+
+	var count int64
+	db.Raw("SELECT 1").Scan(&count)
+
+	if logs.Len() != 0 {
+		t.Errorf("Expected no logs for fast query in warn mode, got %d", logs.Len())
+	}
+}
+
+func TestZapLoggerTagsQueriesWithRequestID(t *testing.T) {
+	db := newTestDB(t)
+	core, logs := observer.New(zapcore.DebugLevel)
+	zl := database.NewZapLogger(zap.New(core), time.Hour)
+	db = db.Session(&gorm.Session{Logger: zl.LogMode(gormlogger.Info)})
+
+	r := gin.New()
+	r.Use(middleware.RequestID(), middleware.Transaction(db))
+	r.GET("/count", func(c *gin.Context) {
+		var count int64
+		middleware.GetTx(c).Raw("SELECT 1").Scan(&count)
+		c.JSON(http.StatusOK, gin.H{"count": count})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/count", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	if got := w.Header().Get(middleware.RequestIDHeader); got != "req-123" {
+		t.Errorf("Expected request id echoed back, got %q", got)
+	}
+
+	queries := logs.FilterMessage("query").All()
+	if len(queries) == 0 {
+		t.Fatal("Expected a query log line")
+	}
+	for _, entry := range queries {
+		if got := entry.ContextMap()["request_id"]; got != "req-123" {
+			t.Errorf("Expected request_id req-123 on %v, got %v", entry.ContextMap()["sql"], got)
+		}
+	}
+}
+
+func TestRequestIDTagsHandlerQueries(t *testing.T) {
+	db := newTestDB(t)
+	if err := repository.NewUserRepository(db).CreateMany(makeUsers(3), 3); err != nil {
+		t.Fatalf("CreateMany failed: %v", err)
+	}
+	core, logs := observer.New(zapcore.DebugLevel)
+	zl := database.NewZapLogger(zap.New(core), time.Hour)
+	db = db.Session(&gorm.Session{Logger: zl.LogMode(gormlogger.Info)})
+
+	// Wired like main: no Transaction middleware, the repository runs the query
+	r := gin.New()
+	r.Use(middleware.RequestID())
+	handler.NewUserHandler(service.NewUserService(repository.NewUserRepository(db))).RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-456")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	queries := logs.FilterMessage("query").All()
+	if len(queries) == 0 {
+		t.Fatal("Expected a query log line")
+	}
+	for _, entry := range queries {
+		if got := entry.ContextMap()["request_id"]; got != "req-456" {
+			t.Errorf("Expected request_id req-456 on %v, got %v", entry.ContextMap()["sql"], got)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenIssuer creates and checks tokens
+// Handlers depend on this instead of GenerateToken/ValidateToken so tests can swap in a fake
+type TokenIssuer interface {
+	Generate(claims Claims) (string, error)
+	Validate(token string) (*Claims, error)
+}
+
+// NewClaims returns claims for the user that expire after ttl
+func NewClaims(userID uint64, email, role string, ttl time.Duration) Claims {
+	return Claims{
+		UserID: userID,
+		Email:  email,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		},
+	}
+}
+
+// JWTIssuer is the default TokenIssuer: HS256 tokens signed with secretKey
+type JWTIssuer struct{}
+
+func (JWTIssuer) Generate(claims Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secretKey)
+}
+
+func (JWTIssuer) Validate(token string) (*Claims, error) {
+	return ValidateToken(token)
+}
@@ -20,20 +20,7 @@ var (
 )
 
 func GenerateToken(userID uint64, email, role string, ttl time.Duration) (string, error) {
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"userId": userID,
-			"email":  email,
-			"role":   role,
-			"exp":    time.Now().Add(ttl).Unix(),
-		})
-
-	tokenString, err := token.SignedString(secretKey)
-	if err != nil {
-		return "", err
-	}
-	return tokenString, nil
+	return JWTIssuer{}.Generate(NewClaims(userID, email, role, ttl))
 }
 
 func ValidateToken(tokenString string) (*Claims, error) {
@@ -49,3 +36,8 @@ func ValidateToken(tokenString string) (*Claims, error) {
 	}
 	return claims, nil
 }
+
+// SetSecretKey replaces the HS256 signing key; call once at startup, before serving requests
+func SetSecretKey(secret string) {
+	secretKey = []byte(secret)
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/viraj/go-mono-repo/projects/hello-world/validation"
+)
+
+// Config holds the auth server's settings
+// A trimmed-down version of exercise 10's config (same file layout and env overrides)
+type Config struct {
+	Server ServerConfig `mapstructure:"server"`
+	JWT    JWTConfig    `mapstructure:"jwt"`
+	Log    LogConfig    `mapstructure:"log"`
+}
+
+type ServerConfig struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+// Addr returns the host:port the server listens on
+func (s ServerConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", s.Host, s.Port)
+}
+
+type JWTConfig struct {
+	Secret string `mapstructure:"secret"` // HS256 signing key; set JWT_SECRET in real deployments
+}
+
+// LogConfig picks the request log format
+type LogConfig struct {
+	Format string `mapstructure:"format"` // text (Gin's default lines) or json
+}
+
+// LoadConfig reads config.yaml from path, then applies env overrides (SERVER_PORT, JWT_SECRET, ...)
+// A missing config.yaml is fine: defaults + env are enough to run
+func LoadConfig(path string) (*Config, error) {
+	v := viper.New()
+
+	v.SetDefault("server.host", "0.0.0.0")
+	v.SetDefault("server.port", 8082)
+	v.SetDefault("jwt.secret", "gbuhtegriesdjocklmsfvbgtnrhuiefjndkclmefjrgthiuefjndkrgthy")
+	v.SetDefault("log.format", "text")
+
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(path)
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate fails fast on settings the server can't start with
+func (c *Config) Validate() error {
+	return validation.New().
+		Check("server.port", validation.Range(c.Server.Port, 1, 65535)).
+		Check("jwt.secret", validation.MinLength(c.JWT.Secret, 32).WithMessage("must be at least 32 characters for security")).
+		Check("log.format", validation.OneOf(c.Log.Format, "text", "json")).
+		Err()
+}
@@ -22,15 +22,44 @@ type ErrorResponse struct {
 
 type UserHandler struct {
 	UserRepo *repo.UserRepo
+	Tokens   auth.TokenIssuer // nil means auth.JWTIssuer
+}
+
+// NewUserHandler creates a handler that issues real JWTs
+func NewUserHandler(userRepo *repo.UserRepo) *UserHandler {
+	return &UserHandler{UserRepo: userRepo, Tokens: auth.JWTIssuer{}}
+}
+
+func (uh *UserHandler) tokens() auth.TokenIssuer {
+	if uh.Tokens == nil {
+		return auth.JWTIssuer{}
+	}
+	return uh.Tokens
+}
+
+// issuePair creates a short-lived access token and a long-lived refresh token
+func (uh *UserHandler) issuePair(user models.User) (string, string, error) {
+	at, err := uh.tokens().Generate(auth.NewClaims(user.ID, user.Email, user.Role, time.Minute*5))
+	if err != nil {
+		return "", "", err
+	}
+	rt, err := uh.tokens().Generate(auth.NewClaims(user.ID, user.Email, user.Role, time.Hour*24*30*6))
+	if err != nil {
+		return "", "", err
+	}
+	return at, rt, nil
 }
 
 func (uh *UserHandler) RegisterHandler(g *gin.Context) {
 	user := models.User{}
-	err := g.BindJSON(&user)
-	at, err := auth.GenerateToken(user.ID, user.Email, user.Role, time.Minute*5)
-	rt, err := auth.GenerateToken(user.ID, user.Email, user.Role, time.Hour*24*30*6)
+	if err := g.ShouldBindJSON(&user); err != nil {
+		g.JSON(400, gin.H{"Error": "invalid user payload"})
+		return
+	}
+	at, rt, err := uh.issuePair(user)
 	if err != nil {
-		g.AbortWithError(400, err)
+		g.AbortWithError(500, err)
+		return
 	}
 	uh.UserRepo.AddUser(&user)
 	g.JSON(200, gin.H{"accessToken": at, "refreshToken": rt})
@@ -43,8 +72,7 @@ func (uh *UserHandler) LoginHandler(g *gin.Context) {
 		g.JSON(400, gin.H{"Error": "User have not enough details passed"})
 		return
 	}
-	at, err := auth.GenerateToken(user.ID, user.Email, user.Role, time.Minute*5)
-	rt, err := auth.GenerateToken(user.ID, user.Email, user.Role, time.Hour*24*30*6)
+	at, rt, err := uh.issuePair(user)
 	if err != nil {
 		g.AbortWithError(500, err)
 		return
@@ -54,16 +82,17 @@ func (uh *UserHandler) LoginHandler(g *gin.Context) {
 
 func (uh *UserHandler) RefreshToken(g *gin.Context) {
 	token := middleware.ExtractToken(g)
-	claims, err := auth.ValidateToken(token)
+	claims, err := uh.tokens().Validate(token)
 
 	if err != nil {
 		str := "Given Refresh Token is not valid , Please login !!"
 		g.AbortWithStatusJSON(401, str)
 		return
 	}
-	at, err := auth.GenerateToken(claims.UserID, claims.Email, claims.Role, time.Hour*24*30*6)
+	at, err := uh.tokens().Generate(auth.NewClaims(claims.UserID, claims.Email, claims.Role, time.Hour*24*30*6))
 	if err != nil {
 		g.AbortWithError(500, err)
+		return
 	}
 	g.JSON(200, gin.H{"accessToken": at})
 }
@@ -1,20 +1,39 @@
 package main
 
 import (
+	"log"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/auth"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/config"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/handler"
 	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/middleware"
 	repo2 "github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/repo"
 )
 
 func main() {
-	r := gin.Default()
+	cfg, err := config.LoadConfig("./configs")
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	auth.SetSecretKey(cfg.JWT.Secret)
+
+	r := gin.New()
+	r.Use(middleware.RequestLogger(cfg.Log.Format), gin.Recovery())
 
 	repo := repo2.GetUserRepoInstance()
-	userHandler := handler.UserHandler{repo}
+	userHandler := handler.NewUserHandler(repo)
+
+	// 5 req/s with bursts of 10, per user (or per IP when not logged in)
+	limiter := middleware.NewRateLimiter(5, 10)
+	// Forget clients idle for 10 minutes, checking once a minute
+	stopCleanup := limiter.StartCleanup(time.Minute, 10*time.Minute)
+	defer stopCleanup()
 
 	r.Group("api/v1")
 	public := r.Group("api/v1")
+	public.Use(middleware.UserRateLimit(limiter))
 	{
 		public.POST("/register", userHandler.RegisterHandler)
 		public.GET("/login", userHandler.LoginHandler)
@@ -22,8 +41,8 @@ func main() {
 	}
 	private := r.Group("api/v1")
 	{
-		private.GET("/profile", middleware.AuthMiddleware(), userHandler.GetProfileHandler)
-		private.GET("/order", middleware.AuthMiddleware(), userHandler.OrderHandler)
+		private.GET("/profile", middleware.AuthMiddleware(), middleware.UserRateLimit(limiter), userHandler.GetProfileHandler)
+		private.GET("/order", middleware.AuthMiddleware(), middleware.UserRateLimit(limiter), userHandler.OrderHandler)
 	}
 
 	admin := r.Group("api/v1")
@@ -33,8 +52,7 @@ func main() {
 		// make sure it is after registration of middlewares;
 		admin.GET("/admin")
 	}
-	err := r.Run(":8082")
-	if err != nil {
-		return
+	if err := r.Run(cfg.Server.Addr()); err != nil {
+		log.Fatalf("server stopped: %v", err)
 	}
 }
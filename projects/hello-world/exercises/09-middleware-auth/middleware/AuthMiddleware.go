@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -21,6 +22,21 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next() // Continue to next handler
 	}
 }
+
+// AuthForWrites lets reads (GET, HEAD, OPTIONS) through and runs AuthMiddleware for
+// everything else, so a route group can be publicly readable but only writable when logged in
+func AuthForWrites() gin.HandlerFunc {
+	requireAuth := AuthMiddleware()
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+		default:
+			requireAuth(c)
+		}
+	}
+}
+
 func ExtractToken(c *gin.Context) string {
 	brr := c.Request.Header.Get("Authorization")
 	str, ok := strings.CutPrefix(brr, "Bearer ")
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter keeps one token bucket per client key
+// Keyed per user instead of per IP so people behind the same NAT/proxy
+// don't share (and exhaust) a single bucket
+type RateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter allows each key rps requests per second with bursts up to burst
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		limit:   rate.Limit(rps),
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from key's bucket, creating the bucket on first use
+func (rl *RateLimiter) Allow(key string) bool {
+	rl.mu.Lock()
+	b, ok := rl.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rl.limit, rl.burst)}
+		rl.buckets[key] = b
+	}
+	b.lastSeen = time.Now()
+	rl.mu.Unlock()
+
+	return b.limiter.Allow()
+}
+
+// Cleanup forgets buckets unused for longer than idle; call it periodically
+func (rl *RateLimiter) Cleanup(idle time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	for key, b := range rl.buckets {
+		if time.Since(b.lastSeen) > idle {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+// StartCleanup runs Cleanup(idle) every interval in the background until stop is called
+// Without it every client ever seen keeps a bucket for the life of the process
+func (rl *RateLimiter) StartCleanup(interval, idle time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				rl.Cleanup(idle)
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// UserRateLimit throttles by the "userId" AuthMiddleware puts in the context
+// Must run after AuthMiddleware; on public routes there is no userId and
+// the client IP is used instead
+func UserRateLimit(rl *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !rl.Allow(rateLimitKey(c)) {
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(429, gin.H{"error": "too many requests"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func rateLimitKey(c *gin.Context) string {
+	if userID, ok := c.Get("userId"); ok {
+		return fmt.Sprintf("user:%v", userID)
+	}
+	return "ip:" + c.ClientIP()
+}
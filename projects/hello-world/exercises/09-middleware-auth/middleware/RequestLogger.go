@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLogger logs one line per request, as Gin's usual text or as JSON
+// JSON lines are what log shippers (Fluent Bit, Loki, ...) expect
+func RequestLogger(format string) gin.HandlerFunc {
+	if format != "json" {
+		return gin.Logger()
+	}
+	return gin.LoggerWithFormatter(func(p gin.LogFormatterParams) string {
+		line, _ := json.Marshal(map[string]any{
+			"time":       p.TimeStamp.Format(time.RFC3339),
+			"status":     p.StatusCode,
+			"method":     p.Method,
+			"path":       p.Path,
+			"latency_ms": p.Latency.Milliseconds(),
+			"client_ip":  p.ClientIP,
+			"error":      p.ErrorMessage,
+		})
+		return string(line) + "\n"
+	})
+}
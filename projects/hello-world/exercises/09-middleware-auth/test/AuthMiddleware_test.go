@@ -0,0 +1,55 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/middleware"
+)
+
+func TestAuthForWrites(t *testing.T) {
+	r := gin.New()
+	articles := r.Group("/articles", middleware.AuthForWrites())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	articles.GET("", ok)
+	articles.HEAD("", ok)
+	articles.POST("", ok)
+	articles.PUT("/:id", ok)
+	articles.PATCH("/:id", ok)
+	articles.DELETE("/:id", ok)
+
+	token := tokenFor(t, 1)
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		wantStatus int
+	}{
+		{"GET without token", http.MethodGet, "/articles", "", http.StatusOK},
+		{"HEAD without token", http.MethodHead, "/articles", "", http.StatusOK},
+		{"POST without token", http.MethodPost, "/articles", "", http.StatusUnauthorized},
+		{"PUT without token", http.MethodPut, "/articles/1", "", http.StatusUnauthorized},
+		{"PATCH without token", http.MethodPatch, "/articles/1", "", http.StatusUnauthorized},
+		{"DELETE without token", http.MethodDelete, "/articles/1", "", http.StatusUnauthorized},
+		{"POST with invalid token", http.MethodPost, "/articles", "not-a-jwt", http.StatusUnauthorized},
+		{"POST with token", http.MethodPost, "/articles", token, http.StatusOK},
+		{"DELETE with token", http.MethodDelete, "/articles/1", token, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
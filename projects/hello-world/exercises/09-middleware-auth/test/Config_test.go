@@ -0,0 +1,80 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/config"
+)
+
+// writeConfig puts yaml in a temp dir as config.yaml and returns the dir
+func writeConfig(t *testing.T, yaml string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return dir
+}
+
+func TestLoadConfigServerAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		env      map[string]string
+		wantAddr string
+	}{
+		{"defaults", "", nil, "0.0.0.0:8082"},
+		{"custom port", "server:\n  port: 9090\n", nil, "0.0.0.0:9090"},
+		{"custom host and port", "server:\n  host: 127.0.0.1\n  port: 3000\n", nil, "127.0.0.1:3000"},
+		{"env override", "server:\n  port: 9090\n", map[string]string{"SERVER_PORT": "7000"}, "0.0.0.0:7000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := config.LoadConfig(writeConfig(t, tt.yaml))
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if got := cfg.Server.Addr(); got != tt.wantAddr {
+				t.Errorf("Expected addr %s, got %s", tt.wantAddr, got)
+			}
+		})
+	}
+}
+
+func TestLoadConfigWithoutFile(t *testing.T) {
+	cfg, err := config.LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Server.Port != 8082 || cfg.Log.Format != "text" {
+		t.Errorf("Expected defaults 8082/text, got %d/%s", cfg.Server.Port, cfg.Log.Format)
+	}
+}
+
+func TestLoadConfigRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"port out of range", "server:\n  port: 70000\n", "server.port"},
+		{"short secret", "jwt:\n  secret: short\n", "jwt.secret"},
+		{"unknown log format", "log:\n  format: xml\n", "log.format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.LoadConfig(writeConfig(t, tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error about %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/middleware"
+)
+
+const (
+	webhookSecret = "webhook-secret"
+	webhookHeader = "X-Signature"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHMACVerify(t *testing.T) {
+	payload := `{"event":"order.paid","id":42}`
+
+	tests := []struct {
+		name       string
+		body       string
+		signature  string
+		wantStatus int
+	}{
+		{"valid signature", payload, sign(webhookSecret, payload), http.StatusOK},
+		{"valid with sha256= prefix", payload, "sha256=" + sign(webhookSecret, payload), http.StatusOK},
+		{"tampered body", strings.Replace(payload, "42", "43", 1), sign(webhookSecret, payload), http.StatusUnauthorized},
+		{"wrong secret", payload, sign("other-secret", payload), http.StatusUnauthorized},
+		{"missing signature", payload, "", http.StatusUnauthorized},
+		{"not hex", payload, "zzzz", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			r := gin.New()
+			r.POST("/webhook", middleware.HMACVerify(webhookSecret, webhookHeader), func(c *gin.Context) {
+				body, _ := io.ReadAll(c.Request.Body)
+				received = string(body)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(webhookHeader, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && received != tt.body {
+				t.Errorf("Expected handler to read the original body %q, got %q", tt.body, received)
+			}
+			if tt.wantStatus != http.StatusOK && received != "" {
+				t.Error("Expected handler not to run")
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/auth"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/middleware"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func tokenFor(t *testing.T, userID uint64) string {
+	t.Helper()
+	token, err := auth.GenerateToken(userID, "user@test.com", "user", time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	return token
+}
+
+func TestUserRateLimitPerUserBuckets(t *testing.T) {
+	// Practically no refill during the test: each user gets exactly 2 requests
+	limiter := middleware.NewRateLimiter(0.001, 2)
+	r := gin.New()
+	r.GET("/profile", middleware.AuthMiddleware(), middleware.UserRateLimit(limiter), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	alice, bob := tokenFor(t, 1), tokenFor(t, 2)
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{"alice first request", alice, http.StatusOK},
+		{"alice burst", alice, http.StatusOK},
+		{"alice throttled", alice, http.StatusTooManyRequests},
+		{"bob has his own bucket", bob, http.StatusOK},
+		{"bob burst", bob, http.StatusOK},
+		{"bob throttled", bob, http.StatusTooManyRequests},
+	}
+
+	// All requests come from the same IP, like users behind one NAT
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: Expected %d, got %d", tt.name, tt.wantStatus, w.Code)
+		}
+	}
+}
+
+func TestUserRateLimitFallsBackToIP(t *testing.T) {
+	limiter := middleware.NewRateLimiter(0.001, 1)
+	r := gin.New()
+	r.GET("/login", middleware.UserRateLimit(limiter), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		wantStatus int
+	}{
+		{"first client", "192.0.2.1:1234", http.StatusOK},
+		{"first client throttled", "192.0.2.1:5678", http.StatusTooManyRequests},
+		{"second client", "192.0.2.2:1234", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/login", nil)
+		req.RemoteAddr = tt.remoteAddr
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: Expected %d, got %d", tt.name, tt.wantStatus, w.Code)
+		}
+	}
+}
+
+func TestRateLimiterStartCleanupEvictsIdleBuckets(t *testing.T) {
+	// No refill to speak of: a key gets one request per bucket
+	limiter := middleware.NewRateLimiter(0.001, 1)
+	if !limiter.Allow("user:1") {
+		t.Fatal("Expected first request to be allowed")
+	}
+	if limiter.Allow("user:1") {
+		t.Fatal("Expected second request to be throttled")
+	}
+
+	stop := limiter.StartCleanup(5*time.Millisecond, time.Millisecond)
+	defer stop()
+
+	// Once the idle bucket is dropped the key starts over with a full one
+	deadline := time.Now().Add(2 * time.Second)
+	for !limiter.Allow("user:1") {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the idle bucket to be evicted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stop()
+	stop() // Second call must not panic
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/auth"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/handler"
+	"github.com/viraj/go-mono-repo/projects/hello-world/exercises/09-middleware-auth/repo"
+)
+
+// fakeIssuer returns canned tokens and errors and records what it was asked to sign
+type fakeIssuer struct {
+	tokens      []string
+	generateErr error
+	claims      *auth.Claims
+	validateErr error
+	generated   []auth.Claims
+}
+
+func (f *fakeIssuer) Generate(claims auth.Claims) (string, error) {
+	if f.generateErr != nil {
+		return "", f.generateErr
+	}
+	f.generated = append(f.generated, claims)
+	return f.tokens[len(f.generated)-1], nil
+}
+
+func (f *fakeIssuer) Validate(token string) (*auth.Claims, error) {
+	if f.validateErr != nil {
+		return nil, f.validateErr
+	}
+	return f.claims, nil
+}
+
+func newHandlerRouter(issuer auth.TokenIssuer) *gin.Engine {
+	uh := &handler.UserHandler{UserRepo: repo.GetUserRepoInstance(), Tokens: issuer}
+	r := gin.New()
+	r.POST("/register", uh.RegisterHandler)
+	r.GET("/login", uh.LoginHandler)
+	r.GET("/refresh", uh.RefreshToken)
+	return r
+}
+
+func TestUserHandlerIssuesTokens(t *testing.T) {
+	user := `{"id":7,"name":"Ann","email":"ann@test.com","role":"admin"}`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{"register", http.MethodPost, "/register"},
+		{"login", http.MethodGet, "/login"},
+	}
+
+	for _, tt := range tests {
+		issuer := &fakeIssuer{tokens: []string{"access-token", "refresh-token"}}
+		w := httptest.NewRecorder()
+		newHandlerRouter(issuer).ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, strings.NewReader(user)))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: Expected 200, got %d", tt.name, w.Code)
+		}
+		var body map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: Failed to decode response: %v", tt.name, err)
+		}
+		if body["accessToken"] != "access-token" || body["refreshToken"] != "refresh-token" {
+			t.Errorf("%s: Expected canned tokens, got %v", tt.name, body)
+		}
+		if len(issuer.generated) != 2 {
+			t.Fatalf("%s: Expected 2 tokens generated, got %d", tt.name, len(issuer.generated))
+		}
+		got := issuer.generated[0]
+		if got.UserID != 7 || got.Email != "ann@test.com" || got.Role != "admin" {
+			t.Errorf("%s: Expected claims for the user, got %+v", tt.name, got)
+		}
+		if !issuer.generated[1].ExpiresAt.After(got.ExpiresAt.Time) {
+			t.Errorf("%s: Expected refresh token to outlive access token", tt.name)
+		}
+	}
+}
+
+func TestUserHandlerTokenErrors(t *testing.T) {
+	user := `{"id":7,"name":"Ann","email":"ann@test.com","role":"admin"}`
+	tests := []struct {
+		name       string
+		issuer     *fakeIssuer
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"register signing fails", &fakeIssuer{generateErr: errors.New("no key")}, http.MethodPost, "/register", user, http.StatusInternalServerError},
+		{"login signing fails", &fakeIssuer{generateErr: errors.New("no key")}, http.MethodGet, "/login", user, http.StatusInternalServerError},
+		{"login missing details", &fakeIssuer{}, http.MethodGet, "/login", `{"id":7}`, http.StatusBadRequest},
+		{"refresh invalid token", &fakeIssuer{validateErr: errors.New("expired")}, http.MethodGet, "/refresh", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		newHandlerRouter(tt.issuer).ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: Expected %d, got %d", tt.name, tt.wantStatus, w.Code)
+		}
+		if len(tt.issuer.generated) != 0 {
+			t.Errorf("%s: Expected no tokens handed out, got %d", tt.name, len(tt.issuer.generated))
+		}
+	}
+}
+
+func TestUserHandlerRefreshUsesValidatedClaims(t *testing.T) {
+	issuer := &fakeIssuer{
+		tokens: []string{"new-access-token"},
+		claims: &auth.Claims{UserID: 9, Email: "bob@test.com", Role: "user"},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/refresh", nil)
+	req.Header.Set("Authorization", "Bearer refresh-token")
+	w := httptest.NewRecorder()
+	newHandlerRouter(issuer).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "new-access-token") {
+		t.Errorf("Expected new access token in response, got %s", w.Body.String())
+	}
+	if len(issuer.generated) != 1 || issuer.generated[0].UserID != 9 {
+		t.Errorf("Expected token for user 9, got %+v", issuer.generated)
+	}
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
 )
 
@@ -13,14 +15,25 @@ type Config struct {
 	Server   ServerConfig   `mapstructure:"server"`
 	Database DatabaseConfig `mapstructure:"database"`
 	JWT      JWTConfig      `mapstructure:"jwt"`
+	Log      LogConfig      `mapstructure:"log"`
 	App      AppConfig      `mapstructure:"app"`
+	Features FeatureFlags   `mapstructure:"features"`
 }
 
 type ServerConfig struct {
-	Host         string `mapstructure:"host"`
-	Port         int    `mapstructure:"port"`
-	ReadTimeout  int    `mapstructure:"read_timeout"`
-	WriteTimeout int    `mapstructure:"write_timeout"`
+	Host         string    `mapstructure:"host"`
+	Port         int       `mapstructure:"port"`
+	ReadTimeout  int       `mapstructure:"read_timeout"`
+	WriteTimeout int       `mapstructure:"write_timeout"`
+	TLS          TLSConfig `mapstructure:"tls"`
+}
+
+// TLSConfig enables HTTPS when both the certificate and key are provided
+// Like Spring's server.ssl.* properties
+type TLSConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
 }
 
 type DatabaseConfig struct {
@@ -38,71 +51,174 @@ type JWTConfig struct {
 	Expiration int    `mapstructure:"expiration"` // hours
 }
 
+// LogConfig has the same shape as exercise 11's log section
+// Like Spring's logging.level.root + a console/JSON encoder choice
+type LogConfig struct {
+	Level       string `mapstructure:"level"`       // debug, info, warn, error, dpanic, panic, fatal
+	Format      string `mapstructure:"format"`      // json, console
+	Development bool   `mapstructure:"development"` // stack traces on warn, panics on dpanic
+}
+
 type AppConfig struct {
 	Name    string `mapstructure:"name"`
 	Version string `mapstructure:"version"`
-	Debug   bool   `mapstructure:"debug"`
+	Debug   bool   `mapstructure:"debug"`    // Debug-only endpoints such as /config
+	GinMode string `mapstructure:"gin_mode"` // debug, release, test; set explicitly, not inferred from Debug
+}
+
+// GinModes are the modes gin.SetMode accepts
+var GinModes = []string{gin.DebugMode, gin.ReleaseMode, gin.TestMode}
+
+// ApplyGinMode switches Gin to the configured mode
+// Call after Validate; gin.SetMode panics on an unknown mode
+func (a *AppConfig) ApplyGinMode() {
+	gin.SetMode(a.GinMode)
+}
+
+// FeatureFlags toggles code paths without a redeploy
+// Like Spring Cloud's @ConditionalOnProperty / feature toggles
+type FeatureFlags map[string]bool
+
+// IsEnabled reports whether a feature flag is on; unknown flags are off
+// Viper lowercases keys, so lookups are case-insensitive
+func (c *Config) IsEnabled(flag string) bool {
+	return c.Features[strings.ToLower(flag)]
 }
 
 // setDefaults sets default values for configuration
 // Like Spring's @Value("${property:defaultValue}")
-func setDefaults() {
-	viper.SetDefault("server.host", "0.0.0.0")
-	viper.SetDefault("server.port", 8080)
-	viper.SetDefault("server.read_timeout", 30)
-	viper.SetDefault("server.write_timeout", 30)
-
-	viper.SetDefault("database.driver", "postgres")
-	viper.SetDefault("database.host", "localhost")
-	viper.SetDefault("database.port", 5432)
-	viper.SetDefault("database.sslmode", "disable")
-
-	viper.SetDefault("jwt.expiration", 24)
-	viper.SetDefault("jwt.secret", []byte("verebhfevegreethergewergerwgewrwnhtgerfdsv"))
-
-	viper.SetDefault("app.name", "Go App")
-	viper.SetDefault("app.version", "1.0.0")
-	viper.SetDefault("app.debug", false)
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("server.host", "0.0.0.0")
+	v.SetDefault("server.port", 8080)
+	v.SetDefault("server.read_timeout", 30)
+	v.SetDefault("server.write_timeout", 30)
+	v.SetDefault("server.tls.enabled", false)
+	v.SetDefault("server.tls.cert_file", "")
+	v.SetDefault("server.tls.key_file", "")
+
+	v.SetDefault("database.driver", "postgres")
+	v.SetDefault("database.host", "localhost")
+	v.SetDefault("database.port", 5432)
+	v.SetDefault("database.user", "")
+	v.SetDefault("database.password", "")
+	v.SetDefault("database.dbname", "")
+	v.SetDefault("database.sslmode", "disable")
+
+	v.SetDefault("jwt.expiration", 24)
+	v.SetDefault("jwt.secret", []byte("verebhfevegreethergewergerwgewrwnhtgerfdsv"))
+
+	v.SetDefault("log.level", "info")
+	v.SetDefault("log.format", "json")
+	v.SetDefault("log.development", false)
+
+	v.SetDefault("app.name", "Go App")
+	v.SetDefault("app.version", "1.0.0")
+	v.SetDefault("app.debug", false)
+	v.SetDefault("app.gin_mode", gin.ReleaseMode)
+
+	v.SetDefault("features", map[string]bool{})
+}
+
+// LoadOption tweaks how LoadConfig treats its sources
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	allowMissingBase bool
+}
+
+// AllowMissingBase lets LoadConfig run on defaults + environment when config.yaml is absent
+// For deployments configured purely through env vars (like a 12-factor app);
+// a warning is still printed so a mistyped path doesn't go unnoticed
+func AllowMissingBase() LoadOption {
+	return func(o *loadOptions) { o.allowMissingBase = true }
 }
 
 // LoadConfig loads configuration from file and environment
 // profile: "dev", "prod", etc. (like Spring profiles)
-func LoadConfig(configPath string, profile string) (*Config, error) {
+//
+// Files are merged in order, later files winning:
+//
+//	config.yaml -> config-{profile}.yaml -> config-local.yaml
+//
+// config-local.yaml holds developer overrides and is gitignored.
+// config.yaml is required unless AllowMissingBase is passed.
+func LoadConfig(configPath string, profile string, opts ...LoadOption) (*Config, error) {
+	var options loadOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// A dedicated instance (instead of the viper singleton) keeps loads independent
+	v := viper.New()
+
 	// Set defaults first (lowest priority)
-	setDefaults()
+	setDefaults(v)
 
 	// Configure viper to read config files
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(configPath)
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(configPath)
 
 	// Read base config
-	if err := viper.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("failed to read base config: %w", err)
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !options.allowMissingBase || !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to read base config: %w", err)
+		}
+		fmt.Printf("Warning: no config.yaml in %s, using defaults and environment\n", configPath)
 	}
 
 	// Merge profile-specific config if provided
 	// Like Spring's application-{profile}.yml
 	if profile != "" {
-		viper.SetConfigName("config-" + profile)
-		if err := viper.MergeInConfig(); err != nil {
+		found, err := mergeOptional(v, "config-"+profile)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
 			// Profile config is optional, don't fail if not found
 			fmt.Printf("Note: No config-%s.yaml found, using base config\n", profile)
 		}
 	}
 
+	// Merge local developer overrides last
+	if _, err := mergeOptional(v, "config-local"); err != nil {
+		return nil, err
+	}
+
 	// Enable environment variable override
 	// Like Spring's SPRING_DATASOURCE_URL -> spring.datasource.url
-	viper.AutomaticEnv()
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	// Resolve ${VAR} / ${VAR:-default} placeholders
+	if err := interpolate(v); err != nil {
+		return nil, fmt.Errorf("failed to interpolate config: %w", err)
+	}
 
 	// Unmarshal into struct (like @ConfigurationProperties)
-	var config Config
-	if err := viper.Unmarshal(&config); err != nil {
+	// Strict: unknown keys and fields with no value/default are errors
+	config, err := UnmarshalStrict[Config](v)
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	return &config, nil
+	return config, nil
+}
+
+// mergeOptional merges the named config file if it exists
+// A missing file is fine; a file that exists but can't be parsed is not
+func mergeOptional(v *viper.Viper, name string) (bool, error) {
+	v.SetConfigName(name)
+	err := v.MergeInConfig()
+	if err == nil {
+		return true, nil
+	}
+	var notFound viper.ConfigFileNotFoundError
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to merge %s.yaml: %w", name, err)
 }
 
 // GetDSN returns database connection string
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrUnsetVariable is returned when ${VAR} has no value and no default
+var ErrUnsetVariable = errors.New("environment variable not set")
+
+// Matches ${VAR} and ${VAR:-default}
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// ExpandEnv substitutes ${VAR} references from the environment
+// Like Spring's ${DB_PASSWORD:default} placeholders in application.yml
+//
+//	${VAR}          -> value of VAR, error if unset
+//	${VAR:-default} -> value of VAR, or default if unset/empty
+func ExpandEnv(s string) (string, error) {
+	var missing []string
+	out := envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		m := envRefPattern.FindStringSubmatch(ref)
+		name, hasDefault, def := m[1], m[2] != "", m[3]
+
+		val, ok := os.LookupEnv(name)
+		if hasDefault && val == "" {
+			return def
+		}
+		if !ok {
+			missing = append(missing, name)
+		}
+		return val
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%w: %s", ErrUnsetVariable, strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// interpolate expands ${VAR} references in every string value before unmarshalling
+func interpolate(v *viper.Viper) error {
+	for _, key := range v.AllKeys() {
+		raw, ok := v.Get(key).(string)
+		if !ok || !strings.Contains(raw, "${") {
+			continue
+		}
+		expanded, err := ExpandEnv(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		v.Set(key, expanded)
+	}
+	return nil
+}
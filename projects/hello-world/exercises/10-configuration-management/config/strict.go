@@ -0,0 +1,25 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// UnmarshalStrict decodes v into a new T and fails loudly instead of leaving zero values:
+//   - ErrorUnused: a key in the config that T has no field for (usually a typo)
+//   - ErrorUnset:  a field in T that no config source or default provided
+//
+// Like Spring's @ConfigurationProperties(ignoreUnknownFields = false)
+func UnmarshalStrict[T any](v *viper.Viper) (*T, error) {
+	var out T
+	err := v.Unmarshal(&out, func(dc *mapstructure.DecoderConfig) {
+		dc.ErrorUnused = true
+		dc.ErrorUnset = true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("strict unmarshal: %w", err)
+	}
+	return &out, nil
+}
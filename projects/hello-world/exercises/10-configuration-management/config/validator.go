@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"os"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/validation"
 )
 
 // Validate checks if the configuration is valid
@@ -22,48 +25,94 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("jwt config: %w", err)
 	}
 
+	// Validate log config
+	if err := c.Log.Validate(); err != nil {
+		return fmt.Errorf("log config: %w", err)
+	}
+
+	// Validate app config
+	if err := c.App.Validate(); err != nil {
+		return fmt.Errorf("app config: %w", err)
+	}
+
 	return nil
 }
 
 func (s *ServerConfig) Validate() error {
-	if s.Port <= 0 || s.Port > 65535 {
-		return fmt.Errorf("invalid port: %d (must be 1-65535)", s.Port)
-	}
-	if s.Host == "" {
-		return fmt.Errorf("host is required")
+	err := validation.New().
+		Check("port", validation.Range(s.Port, 1, 65535)).
+		Check("host", validation.NotEmpty(s.Host)).
+		Check("read_timeout", validation.Positive(s.ReadTimeout)).
+		Check("write_timeout", validation.Positive(s.WriteTimeout)).
+		Err()
+	if err != nil {
+		return err
 	}
-	if s.ReadTimeout <= 0 {
-		return fmt.Errorf("read_timeout must be positive")
-	}
-	if s.WriteTimeout <= 0 {
-		return fmt.Errorf("write_timeout must be positive")
+	if err := s.TLS.Validate(); err != nil {
+		return fmt.Errorf("tls: %w", err)
 	}
 	return nil
 }
 
-func (d *DatabaseConfig) Validate() error {
-	if d.Host == "" {
-		return fmt.Errorf("host is required")
+// Validate checks that cert_file and key_file are set together
+// and, when TLS is enabled, that both files can actually be read
+func (t *TLSConfig) Validate() error {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("cert_file and key_file must be set together")
+	}
+	if !t.Enabled {
+		return nil
 	}
-	if d.Port <= 0 || d.Port > 65535 {
-		return fmt.Errorf("invalid port: %d", d.Port)
+	if t.CertFile == "" {
+		return fmt.Errorf("cert_file and key_file are required when tls is enabled")
 	}
-	if d.DBName == "" {
-		return fmt.Errorf("dbname is required")
+	if err := checkReadable(t.CertFile); err != nil {
+		return fmt.Errorf("cert_file: %w", err)
+	}
+	if err := checkReadable(t.KeyFile); err != nil {
+		return fmt.Errorf("key_file: %w", err)
 	}
 	return nil
 }
 
-func (j *JWTConfig) Validate() error {
-	if j.Secret == "" {
-		return fmt.Errorf("secret is required (set JWT_SECRET env var)")
-	}
-	if len(j.Secret) < 32 {
-		return fmt.Errorf("secret must be at least 32 characters for security")
+// checkReadable fails fast at startup instead of when the listener comes up
+func checkReadable(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
 	}
-	if j.Expiration <= 0 {
-		return fmt.Errorf("expiration must be positive")
-	}
-	return nil
+	return f.Close()
+}
+
+func (d *DatabaseConfig) Validate() error {
+	return validation.New().
+		Check("host", validation.NotEmpty(d.Host)).
+		Check("port", validation.Range(d.Port, 1, 65535)).
+		Check("dbname", validation.NotEmpty(d.DBName)).
+		Err()
+}
+
+func (j *JWTConfig) Validate() error {
+	return validation.New().
+		Check("secret",
+			validation.NotEmpty(j.Secret).WithMessage("is required (set JWT_SECRET env var)"),
+			validation.MinLength(j.Secret, 32).WithMessage("must be at least 32 characters for security")).
+		Check("expiration", validation.Positive(j.Expiration)).
+		Err()
+}
+
+// LogLevels are the levels zap understands, lowest first
+var LogLevels = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+
+func (l *LogConfig) Validate() error {
+	return validation.New().
+		Check("level", validation.OneOf(l.Level, LogLevels...)).
+		Check("format", validation.OneOf(l.Format, "json", "console")).
+		Err()
 }
 
+func (a *AppConfig) Validate() error {
+	return validation.New().
+		Check("gin_mode", validation.OneOf(a.GinMode, GinModes...)).
+		Err()
+}
@@ -21,7 +21,8 @@ func main() {
 	fmt.Printf("🔧 Loading configuration with profile: %s\n", profile)
 
 	// Load configuration
-	cfg, err := config.LoadConfig("./configs", profile)
+	// config.yaml is optional: env vars alone are enough for container deployments
+	cfg, err := config.LoadConfig("./configs", profile, config.AllowMissingBase())
 	if err != nil {
 		log.Fatalf("❌ Failed to load config: %v", err)
 	}
@@ -34,12 +35,8 @@ func main() {
 	// Print loaded config (useful for debugging)
 	printConfig(cfg)
 
-	// Set Gin mode based on debug flag
-	if cfg.App.Debug {
-		gin.SetMode(gin.DebugMode)
-	} else {
-		gin.SetMode(gin.ReleaseMode)
-	}
+	// Set Gin mode from app.gin_mode (validated above)
+	cfg.App.ApplyGinMode()
 
 	// Create router
 	r := gin.Default()
@@ -76,14 +73,20 @@ func main() {
 				"version": cfg.App.Version,
 				"debug":   cfg.App.Debug,
 			},
+			"features": cfg.Features,
 		})
 	})
 
 	// Start server
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
-	fmt.Printf("🚀 Starting %s v%s on %s\n", cfg.App.Name, cfg.App.Version, addr)
-
-	if err := r.Run(addr); err != nil {
+	if cfg.Server.TLS.Enabled {
+		fmt.Printf("🔒 Starting %s v%s on https://%s\n", cfg.App.Name, cfg.App.Version, addr)
+		err = r.RunTLS(addr, cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+	} else {
+		fmt.Printf("🚀 Starting %s v%s on %s\n", cfg.App.Name, cfg.App.Version, addr)
+		err = r.Run(addr)
+	}
+	if err != nil {
 		log.Fatalf("❌ Failed to start server: %v", err)
 	}
 }
@@ -92,8 +95,10 @@ func printConfig(cfg *config.Config) {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("📦 App: %s v%s\n", cfg.App.Name, cfg.App.Version)
 	fmt.Printf("🖥️  Server: %s:%d\n", cfg.Server.Host, cfg.Server.Port)
+	fmt.Printf("🔒 TLS: %v\n", cfg.Server.TLS.Enabled)
 	fmt.Printf("🗄️  Database: %s@%s:%d/%s\n",
 		cfg.Database.User, cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName)
-	fmt.Printf("🐛 Debug: %v\n", cfg.App.Debug)
+	fmt.Printf("📝 Log: %s (%s)\n", cfg.Log.Level, cfg.Log.Format)
+	fmt.Printf("🐛 Debug: %v (gin mode: %s)\n", cfg.App.Debug, cfg.App.GinMode)
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 }
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+
+	"10-configuration-management/config"
+)
+
+func TestConfigIsEnabled(t *testing.T) {
+	dir := writeConfigDir(t, map[string]string{
+		"config": `
+features:
+  new_checkout: true
+  beta_dashboard: false
+`,
+	})
+
+	cfg, err := config.LoadConfig(dir, "")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	tests := []struct {
+		flag     string
+		expected bool
+	}{
+		{"new_checkout", true},
+		{"NEW_CHECKOUT", true},
+		{"beta_dashboard", false},
+		{"does_not_exist", false},
+	}
+	for _, tt := range tests {
+		if got := cfg.IsEnabled(tt.flag); got != tt.expected {
+			t.Errorf("IsEnabled(%q): expected %v, got %v", tt.flag, tt.expected, got)
+		}
+	}
+}
+
+func TestConfigIsEnabledWithoutFeaturesSection(t *testing.T) {
+	dir := writeConfigDir(t, map[string]string{"config": "app:\n  name: test\n"})
+
+	cfg, err := config.LoadConfig(dir, "")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.IsEnabled("anything") {
+		t.Errorf("Expected unknown flag to be disabled")
+	}
+}
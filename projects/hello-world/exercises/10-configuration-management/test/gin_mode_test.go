@@ -0,0 +1,61 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"10-configuration-management/config"
+)
+
+func TestAppConfigValidateGinMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantErr bool
+	}{
+		{"debug", false},
+		{"release", false},
+		{"test", false},
+		{"", true},
+		{"production", true},
+		{"Release", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			app := config.AppConfig{GinMode: tt.mode}
+			err := app.Validate()
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), "gin_mode")) {
+				t.Errorf("Expected gin_mode error, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigGinMode(t *testing.T) {
+	base := "database:\n  dbname: app\njwt:\n  secret: 0123456789abcdef0123456789abcdef\n"
+
+	t.Run("defaults to release even with debug on", func(t *testing.T) {
+		dir := writeConfigDir(t, map[string]string{"config": base + "app:\n  debug: true\n"})
+		cfg, err := config.LoadConfig(dir, "")
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+		if cfg.App.GinMode != "release" {
+			t.Errorf("Expected gin mode release, got %s", cfg.App.GinMode)
+		}
+	})
+
+	t.Run("unknown mode is rejected", func(t *testing.T) {
+		dir := writeConfigDir(t, map[string]string{"config": base + "app:\n  gin_mode: verbose\n"})
+		cfg, err := config.LoadConfig(dir, "")
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "app config") {
+			t.Errorf("Expected app config error, got %v", err)
+		}
+	})
+}
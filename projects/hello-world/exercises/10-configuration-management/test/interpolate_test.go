@@ -0,0 +1,78 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"10-configuration-management/config"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("CFG_TEST_PASSWORD", "s3cret")
+	t.Setenv("CFG_TEST_EMPTY", "")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"set var", "${CFG_TEST_PASSWORD}", "s3cret"},
+		{"set var ignores default", "${CFG_TEST_PASSWORD:-fallback}", "s3cret"},
+		{"unset var with default", "${CFG_TEST_UNSET:-fallback}", "fallback"},
+		{"empty var with default", "${CFG_TEST_EMPTY:-fallback}", "fallback"},
+		{"empty default", "${CFG_TEST_UNSET:-}", ""},
+		{"embedded", "postgres://app:${CFG_TEST_PASSWORD}@db", "postgres://app:s3cret@db"},
+		{"no placeholders", "plain", "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.ExpandEnv(tt.input)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExpandEnvUnsetWithoutDefault(t *testing.T) {
+	_, err := config.ExpandEnv("${CFG_TEST_UNSET}")
+	if !errors.Is(err, config.ErrUnsetVariable) {
+		t.Errorf("Expected ErrUnsetVariable, got %v", err)
+	}
+}
+
+func TestLoadConfigInterpolatesEnv(t *testing.T) {
+	t.Setenv("CFG_TEST_PASSWORD", "s3cret")
+	dir := writeConfigDir(t, map[string]string{
+		"config": `
+database:
+  password: ${CFG_TEST_PASSWORD}
+  dbname: ${CFG_TEST_UNSET:-fallback-db}
+`,
+	})
+
+	cfg, err := config.LoadConfig(dir, "")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Database.Password != "s3cret" {
+		t.Errorf("Expected password s3cret, got %q", cfg.Database.Password)
+	}
+	if cfg.Database.DBName != "fallback-db" {
+		t.Errorf("Expected dbname fallback-db, got %q", cfg.Database.DBName)
+	}
+}
+
+func TestLoadConfigUnsetVarFails(t *testing.T) {
+	dir := writeConfigDir(t, map[string]string{
+		"config": "database:\n  password: ${CFG_TEST_UNSET}\n",
+	})
+
+	if _, err := config.LoadConfig(dir, ""); !errors.Is(err, config.ErrUnsetVariable) {
+		t.Errorf("Expected ErrUnsetVariable, got %v", err)
+	}
+}
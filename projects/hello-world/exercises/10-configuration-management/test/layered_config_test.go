@@ -0,0 +1,108 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"10-configuration-management/config"
+)
+
+// writeConfigDir lays out config files by name (without .yaml) in a temp dir
+func writeConfigDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	dir := writeConfigDir(t, map[string]string{
+		"config": `
+server:
+  port: 8080
+database:
+  host: base-host
+  dbname: base-db
+app:
+  name: base-app
+`,
+		"config-dev": `
+database:
+  host: dev-host
+  dbname: dev-db
+`,
+		"config-local": `
+database:
+  dbname: local-db
+`,
+	})
+
+	cfg, err := config.LoadConfig(dir, "dev")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"base only", cfg.App.Name, "base-app"},
+		{"profile overrides base", cfg.Database.Host, "dev-host"},
+		{"local overrides profile", cfg.Database.DBName, "local-db"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, tt.got)
+		}
+	}
+}
+
+func TestLoadConfigOptionalLayersMissing(t *testing.T) {
+	dir := writeConfigDir(t, map[string]string{
+		"config": "database:\n  dbname: base-db\n",
+	})
+
+	cfg, err := config.LoadConfig(dir, "staging")
+	if err != nil {
+		t.Fatalf("Expected missing profile/local files to be ignored, got %v", err)
+	}
+	if cfg.Database.DBName != "base-db" {
+		t.Errorf("Expected base-db, got %s", cfg.Database.DBName)
+	}
+}
+
+func TestLoadConfigMissingBase(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "does-not-exist")
+	t.Setenv("DATABASE_HOST", "env-host")
+	t.Setenv("SERVER_PORT", "9090")
+
+	if _, err := config.LoadConfig(dir, ""); err == nil {
+		t.Fatal("Expected an error for a missing config.yaml without AllowMissingBase")
+	}
+
+	cfg, err := config.LoadConfig(dir, "", config.AllowMissingBase())
+	if err != nil {
+		t.Fatalf("Expected defaults + env to load, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      interface{}
+		expected interface{}
+	}{
+		{"env overrides default", cfg.Database.Host, "env-host"},
+		{"env overrides int default", cfg.Server.Port, 9090},
+		{"default kept", cfg.App.Name, "Go App"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.got)
+		}
+	}
+}
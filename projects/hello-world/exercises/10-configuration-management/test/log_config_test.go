@@ -0,0 +1,54 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"10-configuration-management/config"
+)
+
+func TestLogConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		log     config.LogConfig
+		wantErr string
+	}{
+		{"json info", config.LogConfig{Level: "info", Format: "json"}, ""},
+		{"console debug", config.LogConfig{Level: "debug", Format: "console"}, ""},
+		{"unknown level", config.LogConfig{Level: "verbose", Format: "json"}, "level"},
+		{"uppercase level", config.LogConfig{Level: "INFO", Format: "json"}, "level"},
+		{"unknown format", config.LogConfig{Level: "info", Format: "xml"}, "format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.log.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error about %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRejectsInvalidLogLevel(t *testing.T) {
+	dir := writeConfigDir(t, map[string]string{
+		"config": "database:\n  dbname: app\njwt:\n  secret: 0123456789abcdef0123456789abcdef\nlog:\n  level: loud\n",
+	})
+
+	cfg, err := config.LoadConfig(dir, "")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Log.Format != "json" {
+		t.Errorf("Expected default format json, got %s", cfg.Log.Format)
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "log config") {
+		t.Errorf("Expected log config error, got %v", err)
+	}
+}
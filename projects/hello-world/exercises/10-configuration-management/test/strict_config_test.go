@@ -0,0 +1,65 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"10-configuration-management/config"
+
+	"github.com/spf13/viper"
+)
+
+type strictTarget struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+func newYAMLViper(t *testing.T, yaml string) *viper.Viper {
+	t.Helper()
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(yaml)); err != nil {
+		t.Fatalf("Failed to read yaml: %v", err)
+	}
+	return v
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"all keys present", "host: localhost\nport: 8080\n", ""},
+		{"unknown key", "host: localhost\nport: 8080\nprot: 9090\n", "prot"},
+		{"missing required key", "host: localhost\n", "port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.UnmarshalStrict[strictTarget](newYAMLViper(t, tt.yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if got.Host != "localhost" || got.Port != 8080 {
+					t.Errorf("Expected localhost:8080, got %s:%d", got.Host, got.Port)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error mentioning %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRejectsUnknownKey(t *testing.T) {
+	dir := writeConfigDir(t, map[string]string{
+		"config": "server:\n  prot: 9090\n",
+	})
+
+	if _, err := config.LoadConfig(dir, ""); err == nil || !strings.Contains(err.Error(), "prot") {
+		t.Errorf("Expected unknown key error, got %v", err)
+	}
+}
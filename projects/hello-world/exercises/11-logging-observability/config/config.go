@@ -1,9 +1,13 @@
 package config
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
+	"github.com/viraj/go-mono-repo/projects/hello-world/validation"
 )
 
 type Config struct {
@@ -15,17 +19,74 @@ type Config struct {
 type ServerConfig struct {
 	Host string `mapstructure:"host"`
 	Port int    `mapstructure:"port"`
+
+	// TrustedProxies lists load balancer IPs/CIDRs allowed to set X-Forwarded-For
+	// Empty trusts nobody, so ClientIP() is always the TCP peer
+	// Like Spring's server.forward-headers-strategy + RemoteIpValve.internalProxies
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// Addr returns the host:port the server listens on
+func (s ServerConfig) Addr() string {
+	return fmt.Sprintf("%s:%d", s.Host, s.Port)
 }
 
 type LogConfig struct {
-	Level       string `mapstructure:"level"`
-	Format      string `mapstructure:"format"`
-	Development bool   `mapstructure:"development"`
+	Level       string       `mapstructure:"level"`
+	Format      string       `mapstructure:"format"`
+	Development bool         `mapstructure:"development"`
+	Buffer      BufferConfig `mapstructure:"buffer"`
+	Scrub       ScrubConfig  `mapstructure:"scrub"`
+
+	// Outputs replaces the single stdout sink when set
+	Outputs []OutputConfig `mapstructure:"outputs"`
+}
+
+// OutputConfig is one log destination with its own format and level
+type OutputConfig struct {
+	Path   string `mapstructure:"path"`   // stdout, stderr or a file path
+	Format string `mapstructure:"format"` // json, console
+	Level  string `mapstructure:"level"`  // empty = log.level
+}
+
+// BufferConfig batches log writes in memory instead of hitting stdout per line
+// Like Logback's AsyncAppender
+type BufferConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Size          int           `mapstructure:"size"`           // bytes, 0 = zap default (256 kB)
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // 0 = zap default (30s)
+}
+
+// ScrubConfig lists field keys holding PII that must never be logged raw
+type ScrubConfig struct {
+	Fields []string `mapstructure:"fields"` // e.g. email, password
+	Mode   string   `mapstructure:"mode"`   // mask or hash
 }
 
 type AppConfig struct {
 	Name    string `mapstructure:"name"`
 	Version string `mapstructure:"version"`
+	Profile string `mapstructure:"profile"`  // APP_PROFILE env, like SPRING_PROFILES_ACTIVE
+	GinMode string `mapstructure:"gin_mode"` // debug, release, test; independent of log.development
+}
+
+// GinModes are the modes gin.SetMode accepts
+var GinModes = []string{gin.DebugMode, gin.ReleaseMode, gin.TestMode}
+
+// Validate checks the settings LoadConfig can't enforce through defaults
+func (c *Config) Validate() error {
+	err := validation.New().
+		Check("gin_mode", validation.OneOf(c.App.GinMode, GinModes...)).
+		Err()
+	if err != nil {
+		return fmt.Errorf("app config: %w", err)
+	}
+	return nil
+}
+
+// ApplyGinMode switches Gin to the configured mode
+func (a AppConfig) ApplyGinMode() {
+	gin.SetMode(a.GinMode)
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -36,9 +97,15 @@ func LoadConfig(path string) (*Config, error) {
 	// Defaults
 	viper.SetDefault("server.host", "0.0.0.0")
 	viper.SetDefault("server.port", 8080)
+	viper.SetDefault("server.trusted_proxies", []string{})
 	viper.SetDefault("log.level", "info")
 	viper.SetDefault("log.format", "json")
 	viper.SetDefault("log.development", false)
+	viper.SetDefault("log.buffer.enabled", false)
+	viper.SetDefault("log.scrub.fields", []string{"email", "password"})
+	viper.SetDefault("log.scrub.mode", "mask")
+	viper.SetDefault("app.profile", "dev")
+	viper.SetDefault("app.gin_mode", gin.ReleaseMode)
 
 	// Env override
 	viper.AutomaticEnv()
@@ -52,6 +119,9 @@ func LoadConfig(path string) (*Config, error) {
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
@@ -1,12 +1,15 @@
 module 11-logging-observability
 
-go 1.21
+go 1.23.0
 
 require (
 	github.com/gin-gonic/gin v1.9.1
 	github.com/google/uuid v1.5.0
+	github.com/gorilla/websocket v1.5.3
 	github.com/spf13/viper v1.18.2
+	github.com/viraj/go-mono-repo/projects/hello-world v0.0.0-00010101000000-000000000000
 	go.uber.org/zap v1.26.0
+	golang.org/x/term v0.15.0
 )
 
 require (
@@ -43,9 +46,11 @@ require (
 	golang.org/x/crypto v0.16.0 // indirect
 	golang.org/x/exp v0.0.0-20230905200255-921286631fa9 // indirect
 	golang.org/x/net v0.19.0 // indirect
-	golang.org/x/sys v0.15.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
-	google.golang.org/protobuf v1.31.0 // indirect
+	google.golang.org/protobuf v1.36.8 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/viraj/go-mono-repo/projects/hello-world => ../../
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"errors"
+
+	"11-logging-observability/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Errors returned to clients; messages are safe to expose
+var (
+	ErrInvalidUserID = errors.New("invalid user id")
+	ErrInvalidBody   = errors.New("invalid request body")
+	ErrUserNotFound  = errors.New("user not found")
+)
+
+// respondError writes an RFC 7807 problem whose detail is err's message
+// The body carries the request ID from context, like a Spring @ControllerAdvice
+// adding the trace id to its ProblemDetail
+func respondError(c *gin.Context, status int, err error) {
+	problem.Write(c, problem.New(status, err.Error()))
+}
@@ -35,8 +35,8 @@ func NewUserHandler(logger *zap.Logger) *UserHandler {
 // GetUser retrieves a user by ID
 // Demonstrates request-scoped logging
 func (h *UserHandler) GetUser(c *gin.Context) {
-	// Get request-scoped logger (includes request_id)
-	logger := middleware.GetLogger(c)
+	// Get request-scoped logger (includes request_id, route, method, user_id)
+	logger := middleware.LoggerFromContext(c)
 
 	// Parse user ID
 	idStr := c.Param("id")
@@ -46,7 +46,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 			zap.String("id_param", idStr),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		respondError(c, http.StatusBadRequest, ErrInvalidUserID)
 		return
 	}
 
@@ -70,7 +70,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 // ListUsers lists all users
 func (h *UserHandler) ListUsers(c *gin.Context) {
-	logger := middleware.GetLogger(c)
+	logger := middleware.LoggerFromContext(c)
 
 	logger.Info("listing users")
 
@@ -94,14 +94,14 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 // CreateUser creates a new user
 // Demonstrates error logging
 func (h *UserHandler) CreateUser(c *gin.Context) {
-	logger := middleware.GetLogger(c)
+	logger := middleware.LoggerFromContext(c)
 
 	var user User
 	if err := c.ShouldBindJSON(&user); err != nil {
 		logger.Error("failed to parse user",
 			zap.Error(err),
 		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		respondError(c, http.StatusBadRequest, ErrInvalidBody)
 		return
 	}
 
@@ -123,7 +123,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 // DeleteUser demonstrates error logging
 func (h *UserHandler) DeleteUser(c *gin.Context) {
-	logger := middleware.GetLogger(c)
+	logger := middleware.LoggerFromContext(c)
 
 	idStr := c.Param("id")
 	id, _ := strconv.ParseUint(idStr, 10, 32)
@@ -134,7 +134,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 			zap.Uint64("user_id", id),
 			zap.String("reason", "user not found"),
 		)
-		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		respondError(c, http.StatusNotFound, ErrUserNotFound)
 		return
 	}
 
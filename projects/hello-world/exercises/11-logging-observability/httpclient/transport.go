@@ -0,0 +1,37 @@
+package httpclient
+
+import (
+	"net/http"
+
+	"11-logging-observability/middleware"
+)
+
+// RequestIDTransport copies the inbound request ID onto outbound requests as X-Request-ID
+// so one id follows a call across services
+// Like Spring Cloud Sleuth instrumenting RestTemplate
+type RequestIDTransport struct {
+	Base http.RoundTripper // nil means http.DefaultTransport
+}
+
+func (t *RequestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	requestID, ok := middleware.RequestIDFromContext(req.Context())
+	if !ok || req.Header.Get("X-Request-ID") != "" {
+		return base.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the caller's request
+	out := req.Clone(req.Context())
+	out.Header.Set("X-Request-ID", requestID)
+	return base.RoundTrip(out)
+}
+
+// NewClient returns an http.Client that propagates the request ID
+// Pass the handler's c.Request.Context() to the outbound request for it to apply
+func NewClient() *http.Client {
+	return &http.Client{Transport: &RequestIDTransport{}}
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrFlushTimeout is returned when Close gives up waiting for Sync
+var ErrFlushTimeout = errors.New("log flush timed out")
+
+// Close flushes buffered log entries, giving up after timeout
+// so a stuck sink (full pipe, dead NFS mount) can't block shutdown forever
+// Like Logback's shutdown hook with a delay
+func Close(log *zap.Logger, timeout time.Duration) error {
+	done := make(chan error, 1) // buffered: Sync may finish after we stop waiting
+	go func() {
+		done <- log.Sync()
+	}()
+
+	select {
+	case err := <-done:
+		if isUnsyncable(err) {
+			return nil
+		}
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("%w after %s", ErrFlushTimeout, timeout)
+	}
+}
+
+// isUnsyncable reports the harmless error fsync gives for terminals and pipes
+// (a well-known zap gotcha: "sync /dev/stdout: invalid argument")
+func isUnsyncable(err error) bool {
+	return errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTTY)
+}
@@ -1,19 +1,103 @@
 package logger
 
 import (
+	"fmt"
 	"os"
 
 	"11-logging-observability/config"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/term"
 )
 
 // NewLogger creates a configured zap logger
 // Similar to LoggerFactory.getLogger() in SLF4J
 func NewLogger(cfg config.LogConfig) (*zap.Logger, error) {
+	if len(cfg.Outputs) == 0 {
+		return NewLoggerWithOutput(cfg, zapcore.AddSync(os.Stdout))
+	}
+
+	outputs := make([]Output, 0, len(cfg.Outputs))
+	for _, o := range cfg.Outputs {
+		// zap.Open understands "stdout"/"stderr" as well as file paths
+		// The files stay open for the life of the process
+		ws, _, err := zap.Open(o.Path)
+		if err != nil {
+			return nil, fmt.Errorf("open log output %q: %w", o.Path, err)
+		}
+		outputs = append(outputs, Output{WS: ws, Format: o.Format, Level: o.Level})
+	}
+	return NewTeeLogger(cfg, outputs...)
+}
+
+// NewLoggerWithOutput is NewLogger writing to out instead of stdout
+// Like pointing a Logback appender at a different target
+func NewLoggerWithOutput(cfg config.LogConfig, out zapcore.WriteSyncer) (*zap.Logger, error) {
+	format := cfg.Format
+	if cfg.Development {
+		// Human-readable output for development
+		format = "console"
+	}
+	return NewTeeLogger(cfg, Output{WS: out, Format: format})
+}
+
+// Output is one log destination with its own encoder and level
+// Like a Logback <appender> with its own <encoder> and threshold filter
+type Output struct {
+	WS     zapcore.WriteSyncer
+	Format string // json or console
+	Level  string // empty = cfg.Level
+}
+
+// NewTeeLogger writes every entry to all outputs, e.g. console for humans + JSON file for ingestion
+func NewTeeLogger(cfg config.LogConfig, outputs ...Output) (*zap.Logger, error) {
+	cores := make([]zapcore.Core, 0, len(outputs))
+	for _, o := range outputs {
+		cores = append(cores, newCore(cfg, o))
+	}
+	core := scrubbed(cfg, zapcore.NewTee(cores...))
+
+	// Build logger with options
+	opts := []zap.Option{
+		zap.AddCaller(),                       // Add file:line to logs
+		zap.AddStacktrace(zapcore.ErrorLevel), // Stack trace on errors
+	}
+
+	if cfg.Development {
+		opts = append(opts, zap.Development())
+	}
+
+	return zap.New(core, opts...), nil
+}
+
+// Tee returns an option that also sends every entry to cores, scrubbed like the outputs
+// Use it for sinks created after the logger (e.g. one that needs the logger itself),
+// instead of zap.WrapCore with a plain NewTee, which would bypass log.scrub
+func Tee(cfg config.LogConfig, cores ...zapcore.Core) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		// core is already scrubbed; wrapping the tee again would hash hashes
+		return zapcore.NewTee(core, scrubbed(cfg, zapcore.NewTee(cores...)))
+	})
+}
+
+// scrubbed wraps core in a scrub core when log.scrub lists fields
+// Scrub PII globally, whichever logger/handler the field comes from
+func scrubbed(cfg config.LogConfig, core zapcore.Core) zapcore.Core {
+	if len(cfg.Scrub.Fields) == 0 {
+		return core
+	}
+	return NewScrubCore(core, cfg.Scrub.Fields, cfg.Scrub.Mode)
+}
+
+// newCore builds the encoder/level/sink for a single output
+func newCore(cfg config.LogConfig, o Output) zapcore.Core {
 	// Parse log level
-	level, err := zapcore.ParseLevel(cfg.Level)
+	levelName := o.Level
+	if levelName == "" {
+		levelName = cfg.Level
+	}
+	level, err := zapcore.ParseLevel(levelName)
 	if err != nil {
 		level = zapcore.InfoLevel
 	}
@@ -36,9 +120,12 @@ func NewLogger(cfg config.LogConfig) (*zap.Logger, error) {
 
 	// Choose encoder based on format
 	var encoder zapcore.Encoder
-	if cfg.Format == "console" || cfg.Development {
-		// Human-readable output for development
+	if o.Format == "console" {
 		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		if cfg.Development && isTerminal(o.WS) {
+			// Colors only help humans; piped output (files, log shippers) stays plain
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
 		encoderConfig.EncodeDuration = zapcore.StringDurationEncoder
 		encoder = zapcore.NewConsoleEncoder(encoderConfig)
 	} else {
@@ -46,24 +133,29 @@ func NewLogger(cfg config.LogConfig) (*zap.Logger, error) {
 		encoder = zapcore.NewJSONEncoder(encoderConfig)
 	}
 
-	// Build core
-	core := zapcore.NewCore(
-		encoder,
-		zapcore.AddSync(os.Stdout),
-		level,
-	)
-
-	// Build logger with options
-	opts := []zap.Option{
-		zap.AddCaller(),                           // Add file:line to logs
-		zap.AddStacktrace(zapcore.ErrorLevel),     // Stack trace on errors
+	// Buffer writes off the hot path; logger.Sync() flushes whatever is pending
+	out := o.WS
+	if cfg.Buffer.Enabled {
+		out = &zapcore.BufferedWriteSyncer{
+			WS:            out,
+			Size:          cfg.Buffer.Size,
+			FlushInterval: cfg.Buffer.FlushInterval,
+		}
 	}
 
-	if cfg.Development {
-		opts = append(opts, zap.Development())
-	}
+	return zapcore.NewCore(encoder, out, level)
+}
 
-	return zap.New(core, opts...), nil
+// isTerminal reports whether out writes to a TTY
+// Writers that aren't files can answer for themselves by implementing IsTerminal() bool
+func isTerminal(out zapcore.WriteSyncer) bool {
+	switch w := out.(type) {
+	case interface{ IsTerminal() bool }:
+		return w.IsTerminal()
+	case interface{ Fd() uintptr }:
+		return term.IsTerminal(int(w.Fd()))
+	}
+	return false
 }
 
 // NewDevelopmentLogger creates a pre-configured development logger
@@ -80,4 +172,3 @@ func NewProductionLogger() (*zap.Logger, error) {
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	return config.Build()
 }
-
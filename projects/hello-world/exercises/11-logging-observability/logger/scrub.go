@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Scrub modes
+const (
+	ScrubMask = "mask" // j***@example.com, ***
+	ScrubHash = "hash" // sha256:1a2b3c4d5e6f (stable, so values can still be correlated)
+)
+
+// scrubCore rewrites sensitive fields before they reach the encoder
+// Like a Logback MaskingPatternLayout, but keyed on field names instead of regexes
+type scrubCore struct {
+	zapcore.Core
+	keys map[string]struct{}
+	mode string
+}
+
+// NewScrubCore wraps core so fields named in keys (case-insensitive) are masked or hashed
+func NewScrubCore(core zapcore.Core, keys []string, mode string) zapcore.Core {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	return &scrubCore{Core: core, keys: set, mode: mode}
+}
+
+func (c *scrubCore) With(fields []zapcore.Field) zapcore.Core {
+	return &scrubCore{Core: c.Core.With(c.scrub(fields)), keys: c.keys, mode: c.mode}
+}
+
+// Check must register this wrapper (not the inner core) or Write would bypass scrubbing
+func (c *scrubCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *scrubCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.scrub(fields))
+}
+
+// scrub returns a copy of fields with sensitive values replaced
+func (c *scrubCore) scrub(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		if _, sensitive := c.keys[strings.ToLower(f.Key)]; !sensitive {
+			continue
+		}
+		if out == nil {
+			out = append([]zapcore.Field(nil), fields...)
+		}
+		out[i] = zap.String(f.Key, c.redact(fieldValue(f)))
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func (c *scrubCore) redact(v string) string {
+	if c.mode == ScrubHash {
+		sum := sha256.Sum256([]byte(v))
+		return "sha256:" + hex.EncodeToString(sum[:6])
+	}
+	return mask(v)
+}
+
+// mask keeps just enough to recognise an email; everything else is fully hidden
+func mask(v string) string {
+	if at := strings.LastIndex(v, "@"); at > 0 {
+		return v[:1] + "***" + v[at:]
+	}
+	return "***"
+}
+
+// fieldValue renders any field type (string, int, Stringer, ...) as a string
+func fieldValue(f zapcore.Field) string {
+	if f.Type == zapcore.StringType {
+		return f.String
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return fmt.Sprint(enc.Fields[f.Key])
+}
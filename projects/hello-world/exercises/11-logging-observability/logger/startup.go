@@ -0,0 +1,23 @@
+package logger
+
+import (
+	"runtime"
+
+	"11-logging-observability/config"
+
+	"go.uber.org/zap"
+)
+
+// LogStartup emits one structured "startup" event instead of a printed banner,
+// so log pipelines can see which version/profile came up where
+// Like Spring Boot's "Started Application in 2.3 seconds" line
+func LogStartup(log *zap.Logger, cfg *config.Config) {
+	log.Info("startup",
+		zap.String("app", cfg.App.Name),
+		zap.String("version", cfg.App.Version),
+		zap.String("profile", cfg.App.Profile),
+		zap.String("addr", cfg.Server.Addr()),
+		zap.String("log_level", cfg.Log.Level),
+		zap.String("go_version", runtime.Version()),
+	)
+}
@@ -0,0 +1,32 @@
+package logtest
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// =============================================================================
+// LOG TEST DOUBLE
+// =============================================================================
+// In Java: Logback's ListAppender attached in a test to read back events
+// Go: zaptest/observer keeps entries in memory with their structured fields
+//
+// Usage:
+//
+//	log, logs := logtest.New()
+//	... exercise code that logs through log ...
+//	entry := logs.FilterMessage("fetching user").All()[0]
+//	entry.ContextMap()["user_id"]
+
+// New returns a logger recording every entry (debug and up) and the recorded entries
+func New() (*zap.Logger, *observer.ObservedLogs) {
+	return NewAtLevel(zapcore.DebugLevel)
+}
+
+// NewAtLevel is New, but only records entries at level or above
+// Use it to check that something is (or isn't) logged under a production level
+func NewAtLevel(level zapcore.Level) (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(level)
+	return zap.New(core), logs
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"11-logging-observability/config"
 	"11-logging-observability/handler"
 	"11-logging-observability/logger"
 	"11-logging-observability/middleware"
+	"11-logging-observability/ws"
 
 	"github.com/gin-gonic/gin"
+	"github.com/viraj/go-mono-repo/projects/hello-world/lifecycle"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func main() {
@@ -25,28 +34,36 @@ func main() {
 	if err != nil {
 		panic("failed to init logger: " + err.Error())
 	}
-	defer log.Sync() // Flush buffered logs on exit
-
-	log.Info("application starting",
-		zap.String("app", cfg.App.Name),
-		zap.String("version", cfg.App.Version),
-		zap.String("log_level", cfg.Log.Level),
-	)
-
-	// Set Gin mode (no default logging)
-	if cfg.Log.Development {
-		gin.SetMode(gin.DebugMode)
-	} else {
-		gin.SetMode(gin.ReleaseMode)
-	}
+
+	// Live notifications: warnings and errors are also pushed to WebSocket clients,
+	// through the same PII scrubbing (log.scrub) as the other outputs
+	hub := ws.NewHub(log)
+	log = log.WithOptions(logger.Tee(cfg.Log, hub.Core(zapcore.WarnLevel)))
+
+	// Set Gin mode from app.gin_mode (no default logging)
+	cfg.App.ApplyGinMode()
 
 	// Create router without default middleware
 	r := gin.New()
 
+	// Only trust X-Forwarded-For from our own load balancers, otherwise
+	// ClientIP() is either the proxy's IP or whatever the client claims
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		panic("invalid server.trusted_proxies: " + err.Error())
+	}
+
+	// Probes would flood info logs; errors on them are still logged
+	quietPaths := map[string]zapcore.Level{
+		"/health":  zapcore.DebugLevel,
+		"/metrics": zapcore.DebugLevel,
+	}
+
 	// Add our custom middleware
-	r.Use(gin.Recovery())                // Panic recovery
-	r.Use(middleware.RequestID(log))     // Add request ID
-	r.Use(middleware.RequestLogger(log)) // Log all requests
+	r.Use(gin.Recovery())                                      // Panic recovery
+	r.Use(middleware.RequestID(log))                           // Add request ID
+	r.Use(middleware.PhaseTimer(log))                          // Middleware vs handler time (debug)
+	r.Use(middleware.RequestLoggerWithLevels(log, quietPaths)) // Log all requests
+	r.Use(middleware.HandlerTimer())                           // Must stay last
 
 	// Create handlers
 	userHandler := handler.NewUserHandler(log)
@@ -60,6 +77,9 @@ func main() {
 		})
 	})
 
+	// Real-time notifications
+	r.GET("/ws/notifications", hub.Handler())
+
 	// User routes
 	users := r.Group("/users")
 	{
@@ -70,12 +90,36 @@ func main() {
 	}
 
 	// Start server
-	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
-	log.Info("server starting",
-		zap.String("address", addr),
-	)
+	logger.LogStartup(log, cfg)
+
+	srv := &http.Server{Addr: cfg.Server.Addr(), Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("server failed to start", zap.Error(err))
+		}
+	}()
 
-	if err := r.Run(addr); err != nil {
-		log.Fatal("server failed to start", zap.Error(err))
+	// Graceful shutdown on Ctrl+C / SIGTERM
+	// Like Spring's server.shutdown=graceful
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	log.Info("shutting down server")
+
+	// Stop taking requests, drop WebSocket clients, then flush logs last so
+	// the shutdown messages make it out. 10s in total, the server gets at most 5s of it,
+	// and the log flush runs even if the earlier steps used up the deadline
+	shutdown := lifecycle.New()
+	shutdown.Register("http server", lifecycle.PriorityServer, srv, lifecycle.Budget(5*time.Second))
+	shutdown.RegisterFunc("websocket hub", lifecycle.PriorityWorkers, func(ctx context.Context) error {
+		hub.Close() // Hijacked WebSocket connections aren't closed by Shutdown
+		return nil
+	})
+	shutdown.RegisterFunc("logs", lifecycle.PriorityLogs, func(ctx context.Context) error {
+		return logger.Close(log, 2*time.Second)
+	}, lifecycle.Always(), lifecycle.Budget(2*time.Second))
+	if err := shutdown.Shutdown(10 * time.Second); err != nil {
+		fmt.Fprintf(os.Stderr, "shutdown incomplete: %v\n", err)
 	}
 }
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// UserIDKey is where an auth middleware stores the authenticated user id
+// Same key the AuthMiddleware in 09-middleware-auth uses (c.Set("userId", claims.UserID))
+const UserIDKey = "userId"
+
+// LoggerFromContext returns the request logger enriched with common request fields:
+// user_id (when authenticated), route template and http method
+// Like pushing userId/route into SLF4J's MDC once per request
+func LoggerFromContext(c *gin.Context) *zap.Logger {
+	logger := GetLogger(c)
+
+	fields := []zap.Field{
+		zap.String("method", c.Request.Method),
+	}
+	// FullPath is the route template ("/users/:id"), empty when no route matched
+	if route := c.FullPath(); route != "" {
+		fields = append(fields, zap.String("route", route))
+	}
+	if userID, exists := c.Get(UserIDKey); exists {
+		fields = append(fields, zap.Any("user_id", userID))
+	}
+
+	return logger.With(fields...)
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const handlerDurationKey = "handler_duration"
+
+// PhaseTimer logs how a request's latency splits between middleware and the handler
+// Register it early and HandlerTimer as the last middleware:
+//
+//	r.Use(middleware.PhaseTimer(log), ..., middleware.HandlerTimer())
+//
+// Similar to a Spring HandlerInterceptor measuring preHandle -> afterCompletion
+func PhaseTimer(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		total := time.Since(start)
+		handler := c.GetDuration(handlerDurationKey)
+
+		fields := []zap.Field{
+			zap.String("route", routeTemplate(c)),
+			zap.Duration("total", total),
+			zap.Duration("middleware", total-handler),
+			zap.Duration("handler", handler),
+		}
+		if requestID, exists := c.Get("request_id"); exists {
+			fields = append(fields, zap.String("request_id", requestID.(string)))
+		}
+		logger.Debug("request phases", fields...)
+	}
+}
+
+// HandlerTimer measures the time spent in the route handler itself
+// Must be the last middleware so its c.Next() runs only the handler
+func HandlerTimer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		c.Set(handlerDurationKey, time.Since(start))
+	}
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -19,6 +21,10 @@ func RequestID(logger *zap.Logger) gin.HandlerFunc {
 		// Store in context for other handlers
 		c.Set("request_id", requestID)
 
+		// Also store on the request context so code holding only a
+		// context.Context (outbound HTTP clients, repositories) can read it
+		c.Request = c.Request.WithContext(ContextWithRequestID(c.Request.Context(), requestID))
+
 		// Add to response header for client reference
 		c.Header("X-Request-ID", requestID)
 
@@ -41,3 +47,16 @@ func GetLogger(c *gin.Context) *zap.Logger {
 	// Fallback to no-op logger
 	return zap.NewNop()
 }
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by the RequestID middleware
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok && requestID != ""
+}
@@ -5,11 +5,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // RequestLogger logs HTTP requests with timing and status
 // Similar to Spring's CommonsRequestLoggingFilter
 func RequestLogger(logger *zap.Logger) gin.HandlerFunc {
+	return RequestLoggerWithLevels(logger, nil)
+}
+
+// RequestLoggerWithLevels is RequestLogger with per-path levels for successful requests
+// e.g. {"/health": zapcore.DebugLevel} keeps probes out of info logs;
+// 4xx/5xx on those paths are still logged at warn/error
+// Like setting logging.level for a single noisy endpoint in Spring
+func RequestLoggerWithLevels(logger *zap.Logger, levels map[string]zapcore.Level) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -26,6 +35,7 @@ func RequestLogger(logger *zap.Logger) gin.HandlerFunc {
 		fields := []zap.Field{
 			zap.String("method", c.Request.Method),
 			zap.String("path", path),
+			zap.String("route", routeTemplate(c)),
 			zap.String("query", query),
 			zap.Int("status", status),
 			zap.Duration("latency", latency),
@@ -46,8 +56,21 @@ func RequestLogger(logger *zap.Logger) gin.HandlerFunc {
 		case status >= 400:
 			logger.Warn("http request", fields...)
 		default:
-			logger.Info("http request", fields...)
+			level, ok := levels[path]
+			if !ok {
+				level = zapcore.InfoLevel
+			}
+			logger.Log(level, "http request", fields...)
 		}
 	}
 }
 
+// routeTemplate returns the matched route ("/users/:id") so metrics and log
+// queries can group by endpoint instead of by concrete path
+// FullPath is empty when no route matched (404), so those share one bucket
+func routeTemplate(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return "unmatched"
+}
@@ -0,0 +1,49 @@
+package problem
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of RFC 7807 error bodies
+const ContentType = "application/problem+json"
+
+// Details is an RFC 7807 "problem detail" error body
+// Similar to Spring 6's ProblemDetail returned from a @ControllerAdvice
+type Details struct {
+	Type     string `json:"type"`               // URI identifying the problem type
+	Title    string `json:"title"`              // Short summary, same for every occurrence of Type
+	Status   int    `json:"status"`             // HTTP status code
+	Detail   string `json:"detail,omitempty"`   // What went wrong this time
+	Instance string `json:"instance,omitempty"` // The request path that failed
+
+	// Extension member: lets users quote the failing request to support,
+	// who can then find it in the logs
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// New creates a problem of the generic "about:blank" type,
+// whose title is the status text as RFC 7807 recommends
+func New(status int, detail string) *Details {
+	return &Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Write sends p as application/problem+json, filling Instance from the request path
+// and RequestID from the request_id set by middleware.RequestID
+func Write(c *gin.Context, p *Details) {
+	if p.Instance == "" {
+		p.Instance = c.Request.URL.Path
+	}
+	if p.RequestID == "" {
+		p.RequestID = c.GetString("request_id")
+	}
+	// gin only sets application/json when no Content-Type is present
+	c.Header("Content-Type", ContentType)
+	c.JSON(p.Status, p)
+}
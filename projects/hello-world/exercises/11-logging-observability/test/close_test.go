@@ -0,0 +1,60 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"11-logging-observability/config"
+	"11-logging-observability/logger"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// stalledSyncer never finishes flushing
+type stalledSyncer struct {
+	syncBuffer
+	release chan struct{}
+}
+
+func (s *stalledSyncer) Sync() error {
+	<-s.release
+	return nil
+}
+
+func TestCloseHealthyLogger(t *testing.T) {
+	out := &syncBuffer{}
+	log, err := logger.NewLoggerWithOutput(config.LogConfig{
+		Level:  "info",
+		Format: "json",
+		Buffer: config.BufferConfig{Enabled: true, FlushInterval: time.Hour},
+	}, out)
+	if err != nil {
+		t.Fatalf("NewLoggerWithOutput failed: %v", err)
+	}
+	log.Info("shutting down")
+
+	start := time.Now()
+	if err := logger.Close(log, time.Second); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected Close to return promptly, took %s", elapsed)
+	}
+	if out.String() == "" {
+		t.Errorf("Expected buffered entry to be flushed")
+	}
+}
+
+func TestCloseStalledLogger(t *testing.T) {
+	out := &stalledSyncer{release: make(chan struct{})}
+	defer close(out.release)
+
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), out, zapcore.InfoLevel)
+
+	err := logger.Close(zap.New(core), 20*time.Millisecond)
+	if !errors.Is(err, logger.ErrFlushTimeout) {
+		t.Errorf("Expected ErrFlushTimeout, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"11-logging-observability/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// fakeAuth stands in for the JWT middleware: "Bearer user-<id>" authenticates as <id>
+func fakeAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if id, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer user-"); ok {
+			c.Set(middleware.UserIDKey, id)
+		}
+		c.Next()
+	}
+}
+
+func newContextLoggerRouter(log *zap.Logger) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.RequestID(log), fakeAuth())
+	r.GET("/users/:id", func(c *gin.Context) {
+		middleware.LoggerFromContext(c).Info("fetching user")
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		wantUserID string
+	}{
+		{"with token", "Bearer user-42", "42"},
+		{"without token", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			req := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			newContextLoggerRouter(zap.New(core)).ServeHTTP(httptest.NewRecorder(), req)
+
+			entries := logs.FilterMessage("fetching user").All()
+			if len(entries) != 1 {
+				t.Fatalf("Expected 1 log entry, got %d", len(entries))
+			}
+			fields := entries[0].ContextMap()
+
+			if fields["route"] != "/users/:id" {
+				t.Errorf("Expected route /users/:id, got %v", fields["route"])
+			}
+			if fields["method"] != http.MethodGet {
+				t.Errorf("Expected method GET, got %v", fields["method"])
+			}
+			if _, ok := fields["request_id"]; !ok {
+				t.Errorf("Expected request_id field")
+			}
+
+			userID, ok := fields["user_id"]
+			if tt.wantUserID == "" {
+				if ok {
+					t.Errorf("Expected no user_id, got %v", userID)
+				}
+				return
+			}
+			if userID != tt.wantUserID {
+				t.Errorf("Expected user_id %s, got %v", tt.wantUserID, userID)
+			}
+		})
+	}
+}
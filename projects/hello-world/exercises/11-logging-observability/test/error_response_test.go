@@ -0,0 +1,78 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"11-logging-observability/handler"
+	"11-logging-observability/middleware"
+	"11-logging-observability/problem"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestErrorResponsesAreProblemDetails(t *testing.T) {
+	h := handler.NewUserHandler(zap.NewNop())
+	r := gin.New()
+	r.Use(middleware.RequestID(zap.NewNop()))
+	r.GET("/users/:id", h.GetUser)
+	r.DELETE("/users/:id", h.DeleteUser)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantDetail string
+	}{
+		{"unknown user", http.MethodDelete, "/users/999", http.StatusNotFound, "user not found"},
+		{"invalid id", http.MethodGet, "/users/abc", http.StatusBadRequest, "invalid user id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Content-Type"); got != problem.ContentType {
+				t.Errorf("Expected Content-Type %s, got %s", problem.ContentType, got)
+			}
+
+			// Required members must be present, not just zero-valued
+			var raw map[string]json.RawMessage
+			if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+				t.Fatalf("Failed to decode body: %v", err)
+			}
+			for _, field := range []string{"type", "title", "status", "detail", "instance"} {
+				if _, ok := raw[field]; !ok {
+					t.Errorf("Expected %q in %s", field, w.Body.String())
+				}
+			}
+
+			var body problem.Details
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode body: %v", err)
+			}
+			want := problem.Details{
+				Type:      "about:blank",
+				Title:     http.StatusText(tt.wantStatus),
+				Status:    tt.wantStatus,
+				Detail:    tt.wantDetail,
+				Instance:  tt.path,
+				RequestID: w.Header().Get("X-Request-ID"),
+			}
+			if body != want {
+				t.Errorf("Expected %+v, got %+v", want, body)
+			}
+			if body.RequestID == "" {
+				t.Error("Expected request_id to match the X-Request-ID header")
+			}
+		})
+	}
+}
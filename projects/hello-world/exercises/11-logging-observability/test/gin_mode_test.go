@@ -0,0 +1,47 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"11-logging-observability/config"
+)
+
+func TestLoadConfigGinMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		wantMode string
+		wantErr  bool
+	}{
+		{"defaults to release", "log:\n  development: true\n", "release", false},
+		{"explicit debug", "app:\n  gin_mode: debug\n", "debug", false},
+		{"explicit test", "app:\n  gin_mode: test\n", "test", false},
+		{"unknown mode", "app:\n  gin_mode: production\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.yaml), 0o644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, err := config.LoadConfig(dir)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "gin_mode") {
+					t.Errorf("Expected gin_mode error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if cfg.App.GinMode != tt.wantMode {
+				t.Errorf("Expected gin mode %s, got %s", tt.wantMode, cfg.App.GinMode)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"11-logging-observability/config"
+	"11-logging-observability/logger"
+
+	"go.uber.org/zap"
+)
+
+// fakeTTY is an in-memory WriteSyncer that can pretend to be a terminal
+type fakeTTY struct {
+	bytes.Buffer
+	tty bool
+}
+
+func (f *fakeTTY) Sync() error      { return nil }
+func (f *fakeTTY) IsTerminal() bool { return f.tty }
+
+const ansiEscape = "\x1b["
+
+func TestLoggerColorsOnlyOnTerminal(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.LogConfig
+		tty       bool
+		wantColor bool
+	}{
+		{"development on tty", config.LogConfig{Level: "info", Format: "console", Development: true}, true, true},
+		{"development piped", config.LogConfig{Level: "info", Format: "console", Development: true}, false, false},
+		{"console without development", config.LogConfig{Level: "info", Format: "console"}, true, false},
+		{"json on tty", config.LogConfig{Level: "info", Format: "json"}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &fakeTTY{tty: tt.tty}
+			log, err := logger.NewLoggerWithOutput(tt.cfg, out)
+			if err != nil {
+				t.Fatalf("NewLoggerWithOutput failed: %v", err)
+			}
+			log.Info("hello")
+
+			gotColor := strings.Contains(out.String(), ansiEscape)
+			if gotColor != tt.wantColor {
+				t.Errorf("Expected color=%v, got %v in %q", tt.wantColor, gotColor, out.String())
+			}
+		})
+	}
+}
+
+// syncBuffer is a goroutine-safe in-memory WriteSyncer
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) Sync() error { return nil }
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+func TestBufferedLoggerFlushesOnSync(t *testing.T) {
+	out := &syncBuffer{}
+	cfg := config.LogConfig{
+		Level:  "info",
+		Format: "json",
+		Buffer: config.BufferConfig{Enabled: true, Size: 1 << 20, FlushInterval: time.Hour},
+	}
+	log, err := logger.NewLoggerWithOutput(cfg, out)
+	if err != nil {
+		t.Fatalf("NewLoggerWithOutput failed: %v", err)
+	}
+
+	const lines = 1000
+	for i := 0; i < lines; i++ {
+		log.Info("line", zap.Int("n", i))
+	}
+	if got := strings.Count(out.String(), "\n"); got == lines {
+		t.Errorf("Expected lines to be buffered before Sync, got all %d", got)
+	}
+
+	if err := log.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := strings.Count(out.String(), "\n"); got != lines {
+		t.Errorf("Expected %d lines after Sync, got %d", lines, got)
+	}
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"11-logging-observability/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestPhaseTimer(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	slowMiddleware := func(c *gin.Context) {
+		time.Sleep(20 * time.Millisecond)
+		c.Next()
+	}
+
+	r := gin.New()
+	r.Use(middleware.PhaseTimer(zap.New(core)), slowMiddleware, middleware.HandlerTimer())
+	r.GET("/work", func(c *gin.Context) {
+		time.Sleep(10 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/work", nil))
+
+	entries := logs.FilterMessage("request phases").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	total := fields["total"].(time.Duration)
+	handler := fields["handler"].(time.Duration)
+	mw := fields["middleware"].(time.Duration)
+
+	if handler < 10*time.Millisecond {
+		t.Errorf("Expected handler >= 10ms, got %s", handler)
+	}
+	if handler >= total {
+		t.Errorf("Expected handler (%s) < total (%s)", handler, total)
+	}
+	if mw < 20*time.Millisecond {
+		t.Errorf("Expected middleware >= 20ms, got %s", mw)
+	}
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"11-logging-observability/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestLoggerPerRouteLevels(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		status    int
+		wantLevel zapcore.Level
+		wantLog   bool
+	}{
+		{"health ok is debug", "/health", http.StatusOK, 0, false},
+		{"health error still logged", "/health", http.StatusInternalServerError, zapcore.ErrorLevel, true},
+		{"other route ok is info", "/users", http.StatusOK, zapcore.InfoLevel, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			r := gin.New()
+			r.Use(middleware.RequestLoggerWithLevels(zap.New(core), map[string]zapcore.Level{
+				"/health": zapcore.DebugLevel,
+			}))
+			r.GET(tt.path, func(c *gin.Context) { c.Status(tt.status) })
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			entries := logs.FilterMessage("http request").All()
+			if !tt.wantLog {
+				if len(entries) != 0 {
+					t.Errorf("Expected no info log, got %d entries", len(entries))
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("Expected 1 entry, got %d", len(entries))
+			}
+			if entries[0].Level != tt.wantLevel {
+				t.Errorf("Expected level %s, got %s", tt.wantLevel, entries[0].Level)
+			}
+		})
+	}
+}
+
+func TestRequestLoggerRouteField(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{"template not concrete path", "/users/42", "/users/:id"},
+		{"404 has no template", "/nope", "unmatched"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			r := gin.New()
+			r.Use(middleware.RequestLogger(zap.New(core)))
+			r.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			entries := logs.FilterMessage("http request").All()
+			if len(entries) != 1 {
+				t.Fatalf("Expected 1 entry, got %d", len(entries))
+			}
+			fields := entries[0].ContextMap()
+			if fields["route"] != tt.expected {
+				t.Errorf("Expected route %s, got %v", tt.expected, fields["route"])
+			}
+			if fields["path"] != tt.path {
+				t.Errorf("Expected path %s, got %v", tt.path, fields["path"])
+			}
+		})
+	}
+}
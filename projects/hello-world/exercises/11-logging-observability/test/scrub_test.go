@@ -0,0 +1,55 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"11-logging-observability/config"
+	"11-logging-observability/logger"
+
+	"go.uber.org/zap"
+)
+
+func TestScrubbedFields(t *testing.T) {
+	const email = "john.doe@example.com"
+
+	tests := []struct {
+		name     string
+		mode     string
+		expected string
+	}{
+		{"mask", logger.ScrubMask, `"email":"j***@example.com"`},
+		{"hash", logger.ScrubHash, `"email":"sha256:`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &syncBuffer{}
+			cfg := config.LogConfig{
+				Level:  "info",
+				Format: "json",
+				Scrub:  config.ScrubConfig{Fields: []string{"email", "password"}, Mode: tt.mode},
+			}
+			log, err := logger.NewLoggerWithOutput(cfg, out)
+			if err != nil {
+				t.Fatalf("NewLoggerWithOutput failed: %v", err)
+			}
+
+			log.With(zap.String("password", "hunter2")).Info("creating user",
+				zap.String("email", email),
+				zap.String("name", "John"),
+			)
+
+			got := out.String()
+			if strings.Contains(got, email) || strings.Contains(got, "hunter2") {
+				t.Errorf("Expected PII to be scrubbed, got %s", got)
+			}
+			if !strings.Contains(got, tt.expected) {
+				t.Errorf("Expected %s in %s", tt.expected, got)
+			}
+			if !strings.Contains(got, `"name":"John"`) {
+				t.Errorf("Expected non-sensitive field untouched, got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"testing"
+
+	"11-logging-observability/config"
+	"11-logging-observability/logger"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogStartup(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	cfg := &config.Config{
+		Server: config.ServerConfig{Host: "127.0.0.1", Port: 9090},
+		App:    config.AppConfig{Name: "Logging Demo", Version: "1.2.3", Profile: "prod"},
+	}
+
+	logger.LogStartup(zap.New(core), cfg)
+
+	entries := logs.FilterMessage("startup").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 startup event, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+
+	expected := map[string]string{
+		"app":     "Logging Demo",
+		"version": "1.2.3",
+		"profile": "prod",
+		"addr":    "127.0.0.1:9090",
+	}
+	for key, want := range expected {
+		if fields[key] != want {
+			t.Errorf("Expected %s=%s, got %v", key, want, fields[key])
+		}
+	}
+}
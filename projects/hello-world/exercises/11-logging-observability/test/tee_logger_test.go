@@ -0,0 +1,48 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"11-logging-observability/config"
+	"11-logging-observability/logger"
+
+	"go.uber.org/zap"
+)
+
+func TestTeeLoggerWritesEachFormat(t *testing.T) {
+	console := &syncBuffer{}
+	jsonOut := &syncBuffer{}
+
+	log, err := logger.NewTeeLogger(config.LogConfig{Level: "debug"},
+		logger.Output{WS: console, Format: "console"},
+		logger.Output{WS: jsonOut, Format: "json", Level: "info"},
+	)
+	if err != nil {
+		t.Fatalf("NewTeeLogger failed: %v", err)
+	}
+
+	log.Debug("debug only on console")
+	log.Info("user created", zap.Int("user_id", 7))
+
+	consoleLines := strings.Split(strings.TrimSpace(console.String()), "\n")
+	if len(consoleLines) != 2 {
+		t.Fatalf("Expected 2 console lines, got %d: %q", len(consoleLines), console.String())
+	}
+	if !strings.Contains(consoleLines[1], "INFO") || !strings.Contains(consoleLines[1], "user created") {
+		t.Errorf("Expected console format line, got %q", consoleLines[1])
+	}
+
+	jsonLines := strings.Split(strings.TrimSpace(jsonOut.String()), "\n")
+	if len(jsonLines) != 1 {
+		t.Fatalf("Expected 1 JSON line (info level), got %d: %q", len(jsonLines), jsonOut.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonLines[0]), &entry); err != nil {
+		t.Fatalf("Expected valid JSON, got %q: %v", jsonLines[0], err)
+	}
+	if entry["msg"] != "user created" || entry["level"] != "info" || entry["user_id"] != float64(7) {
+		t.Errorf("Unexpected JSON entry: %v", entry)
+	}
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"11-logging-observability/httpclient"
+	"11-logging-observability/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestRequestIDPropagatesToOutboundCalls(t *testing.T) {
+	var downstreamID string
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstreamID = r.Header.Get("X-Request-ID")
+	}))
+	defer downstream.Close()
+
+	client := httpclient.NewClient()
+	r := gin.New()
+	r.Use(middleware.RequestID(zap.NewNop()))
+	r.GET("/proxy", func(c *gin.Context) {
+		req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, downstream.URL, nil)
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			c.Status(http.StatusBadGateway)
+			return
+		}
+		resp.Body.Close()
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/proxy", nil)
+	req.Header.Set("X-Request-ID", "inbound-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	if downstreamID != "inbound-123" {
+		t.Errorf("Expected downstream X-Request-ID inbound-123, got %q", downstreamID)
+	}
+}
+
+func TestRequestIDTransportWithoutID(t *testing.T) {
+	var downstreamID string
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstreamID = r.Header.Get("X-Request-ID")
+	}))
+	defer downstream.Close()
+
+	resp, err := httpclient.NewClient().Get(downstream.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if downstreamID != "" {
+		t.Errorf("Expected no X-Request-ID, got %q", downstreamID)
+	}
+}
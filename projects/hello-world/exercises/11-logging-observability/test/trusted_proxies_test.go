@@ -0,0 +1,68 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"11-logging-observability/config"
+	"11-logging-observability/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestLoggerUsesForwardedClientIP(t *testing.T) {
+	dir := t.TempDir()
+	yaml := "server:\n  trusted_proxies: [\"10.0.0.0/8\"]\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := config.LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		wantClientIP string
+	}{
+		{"forwarded by trusted proxy", "10.0.0.5:4321", "203.0.113.7", "203.0.113.7"},
+		{"chain of trusted proxies", "10.0.0.5:4321", "203.0.113.7, 10.0.0.9", "203.0.113.7"},
+		{"spoofed header from untrusted peer", "198.51.100.1:4321", "203.0.113.7", "198.51.100.1"},
+		{"no forwarding header", "10.0.0.5:4321", "", "10.0.0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			r := gin.New()
+			if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+				t.Fatalf("Failed to set trusted proxies: %v", err)
+			}
+			r.Use(middleware.RequestLogger(zap.New(core)))
+			r.GET("/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			entries := logs.FilterMessage("http request").All()
+			if len(entries) != 1 {
+				t.Fatalf("Expected 1 request log, got %d", len(entries))
+			}
+			if got := entries[0].ContextMap()["client_ip"]; got != tt.wantClientIP {
+				t.Errorf("Expected client_ip %s, got %v", tt.wantClientIP, got)
+			}
+		})
+	}
+}
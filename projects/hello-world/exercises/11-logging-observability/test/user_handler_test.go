@@ -0,0 +1,41 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"11-logging-observability/handler"
+	"11-logging-observability/logtest"
+	"11-logging-observability/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetUserLogsFetchingUser(t *testing.T) {
+	log, logs := logtest.New()
+	r := gin.New()
+	r.Use(middleware.RequestID(log))
+	r.GET("/users/:id", handler.NewUserHandler(log).GetUser)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+
+	entries := logs.FilterMessage("fetching user").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 'fetching user' entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["user_id"] != uint64(42) {
+		t.Errorf("Expected user_id 42, got %v (%T)", fields["user_id"], fields["user_id"])
+	}
+	if fields["route"] != "/users/:id" {
+		t.Errorf("Expected route /users/:id, got %v", fields["route"])
+	}
+	if fields["request_id"] == nil {
+		t.Error("Expected request_id field")
+	}
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"11-logging-observability/config"
+	"11-logging-observability/logger"
+	"11-logging-observability/middleware"
+	"11-logging-observability/ws"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newWebSocketServer(t *testing.T, hub *ws.Hub, log *zap.Logger) string {
+	t.Helper()
+	r := gin.New()
+	r.Use(middleware.RequestID(log))
+	r.GET("/ws/notifications", hub.Handler())
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws/notifications"
+}
+
+func dialNotifications(t *testing.T, url string, hub *ws.Hub, header http.Header) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		t.Fatalf("Expected dial to succeed, got %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	// Broadcast only reaches registered clients, so wait for the handler to add us
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.Clients() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected client to be registered with the hub")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return conn
+}
+
+func TestWebSocketReceivesBroadcast(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(core)
+	hub := ws.NewHub(log)
+	url := newWebSocketServer(t, hub, log)
+
+	conn := dialNotifications(t, url, hub, http.Header{"X-Request-ID": {"ws-req-1"}})
+
+	hub.Broadcast(ws.Event{Type: "user_created", Message: "user 42 created"})
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var ev ws.Event
+	if err := conn.ReadJSON(&ev); err != nil {
+		t.Fatalf("Expected to read event, got %v", err)
+	}
+	if ev.Type != "user_created" || ev.Message != "user 42 created" {
+		t.Errorf("Expected user_created event, got %+v", ev)
+	}
+	if ev.Time.IsZero() {
+		t.Error("Expected event time to be set")
+	}
+
+	connected := logs.FilterMessage("websocket connected").All()
+	if len(connected) != 1 {
+		t.Fatalf("Expected 1 connect log, got %d", len(connected))
+	}
+	if got := connected[0].ContextMap()["request_id"]; got != "ws-req-1" {
+		t.Errorf("Expected request_id ws-req-1 on connect log, got %v", got)
+	}
+}
+
+func TestWebSocketForwardsWarnLogs(t *testing.T) {
+	hub := ws.NewHub(zap.NewNop())
+	url := newWebSocketServer(t, hub, zap.NewNop())
+	conn := dialNotifications(t, url, hub, nil)
+
+	log := zap.New(hub.Core(zapcore.WarnLevel))
+	log.Info("not pushed")
+	log.With(zap.String("request_id", "abc")).Warn("disk almost full", zap.Int("percent", 91))
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var ev ws.Event
+	if err := conn.ReadJSON(&ev); err != nil {
+		t.Fatalf("Expected to read event, got %v", err)
+	}
+	if ev.Type != "log" || ev.Level != "warn" || ev.Message != "disk almost full" {
+		t.Errorf("Expected warn log event, got %+v", ev)
+	}
+	if ev.RequestID != "abc" {
+		t.Errorf("Expected request_id abc, got %q", ev.RequestID)
+	}
+	if ev.Fields["percent"] != float64(91) {
+		t.Errorf("Expected percent field 91, got %v", ev.Fields["percent"])
+	}
+}
+
+func TestWebSocketLogsAreScrubbed(t *testing.T) {
+	hub := ws.NewHub(zap.NewNop())
+	url := newWebSocketServer(t, hub, zap.NewNop())
+	conn := dialNotifications(t, url, hub, nil)
+
+	// Wired like main: the hub is tee'd in after the logger is built
+	cfg := config.LogConfig{
+		Level:  "info",
+		Format: "json",
+		Scrub:  config.ScrubConfig{Fields: []string{"email", "password"}, Mode: logger.ScrubMask},
+	}
+	log, err := logger.NewLoggerWithOutput(cfg, &syncBuffer{})
+	if err != nil {
+		t.Fatalf("NewLoggerWithOutput failed: %v", err)
+	}
+	log = log.WithOptions(logger.Tee(cfg, hub.Core(zapcore.WarnLevel)))
+
+	log.With(zap.String("password", "hunter2")).Warn("login failed", zap.String("email", "john@example.com"))
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var ev ws.Event
+	if err := conn.ReadJSON(&ev); err != nil {
+		t.Fatalf("Expected to read event, got %v", err)
+	}
+	if got := ev.Fields["email"]; got != "j***@example.com" {
+		t.Errorf("Expected masked email on the socket, got %v", got)
+	}
+	if got := ev.Fields["password"]; got != "***" {
+		t.Errorf("Expected masked password on the socket, got %v", got)
+	}
+}
+
+func TestWebSocketCloseDisconnectsClients(t *testing.T) {
+	hub := ws.NewHub(zap.NewNop())
+	url := newWebSocketServer(t, hub, zap.NewNop())
+	conn := dialNotifications(t, url, hub, nil)
+
+	hub.Close()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("Expected normal close frame, got %v", err)
+	}
+	if hub.Clients() != 0 {
+		t.Errorf("Expected 0 clients after Close, got %d", hub.Clients())
+	}
+}
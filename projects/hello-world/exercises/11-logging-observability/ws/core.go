@@ -0,0 +1,62 @@
+package ws
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// hubCore is a zapcore.Core that forwards log entries to WebSocket clients
+// Tee it next to the normal core so warnings/errors show up live in dashboards
+type hubCore struct {
+	zapcore.LevelEnabler
+	hub    *Hub
+	fields []zapcore.Field
+}
+
+// Core returns a zapcore.Core broadcasting entries at or above level as "log" events
+// Keep level above Info: the hub logs connects/disconnects at Info
+func (h *Hub) Core(level zapcore.LevelEnabler) zapcore.Core {
+	return &hubCore{LevelEnabler: level, hub: h}
+}
+
+func (c *hubCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append([]zapcore.Field{}, c.fields...), fields...)
+	return &clone
+}
+
+func (c *hubCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *hubCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	ev := Event{
+		Type:    "log",
+		Message: ent.Message,
+		Level:   ent.Level.String(),
+		Fields:  enc.Fields,
+		Time:    ent.Time,
+	}
+	if id, ok := enc.Fields["request_id"].(string); ok {
+		ev.RequestID = id
+		delete(enc.Fields, "request_id")
+	}
+	if len(enc.Fields) == 0 {
+		ev.Fields = nil
+	}
+
+	c.hub.Broadcast(ev)
+	return nil
+}
+
+func (c *hubCore) Sync() error { return nil }
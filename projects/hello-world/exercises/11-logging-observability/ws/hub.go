@@ -0,0 +1,184 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"11-logging-observability/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	writeWait  = 10 * time.Second    // Max time to write one message
+	pongWait   = 60 * time.Second    // Client must answer a ping within this
+	pingPeriod = (pongWait * 9) / 10 // Ping slightly before the pong deadline
+	sendBuffer = 32                  // Per-client queue; slow clients drop events
+)
+
+// Event is one real-time notification pushed to clients
+type Event struct {
+	Type      string                 `json:"type"`
+	Message   string                 `json:"message"`
+	Level     string                 `json:"level,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+	Time      time.Time              `json:"time"`
+}
+
+// Hub keeps track of connected WebSocket clients and broadcasts events to them
+// Similar to Spring's SimpMessagingTemplate.convertAndSend("/topic/...")
+type Hub struct {
+	logger   *zap.Logger
+	upgrader websocket.Upgrader
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	closed  bool
+}
+
+type client struct {
+	conn *websocket.Conn
+	send chan Event
+	done chan struct{}
+}
+
+// NewHub creates an empty hub
+func NewHub(logger *zap.Logger) *Hub {
+	return &Hub{
+		logger:  logger.With(zap.String("component", "ws")),
+		clients: make(map[*client]struct{}),
+	}
+}
+
+// Broadcast queues ev for every connected client without blocking
+func (h *Hub) Broadcast(ev Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for cl := range h.clients {
+		select {
+		case cl.send <- ev:
+		default: // Client too slow, drop rather than stall the caller
+		}
+	}
+}
+
+// Clients returns the number of connected clients
+func (h *Hub) Clients() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// Close sends a close frame to every client and refuses new connections
+// Call during graceful shutdown: http.Server.Shutdown doesn't wait for hijacked connections
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	clients := h.clients
+	h.clients = make(map[*client]struct{})
+	h.mu.Unlock()
+
+	for cl := range clients {
+		close(cl.done)
+	}
+}
+
+// Handler upgrades GET /ws/notifications to a WebSocket
+func (h *Hub) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger := middleware.GetLogger(c)
+
+		conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// Upgrade already wrote a 400 response
+			logger.Info("websocket upgrade failed", zap.Error(err))
+			return
+		}
+
+		cl := &client{conn: conn, send: make(chan Event, sendBuffer), done: make(chan struct{})}
+		if !h.add(cl) {
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+				time.Now().Add(writeWait))
+			conn.Close()
+			return
+		}
+		logger.Info("websocket connected", zap.String("remote_addr", conn.RemoteAddr().String()))
+
+		go h.readPump(cl)
+		h.writePump(cl) // Blocks until the client goes away or the hub closes
+
+		logger.Info("websocket disconnected")
+	}
+}
+
+func (h *Hub) add(cl *client) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return false
+	}
+	h.clients[cl] = struct{}{}
+	return true
+}
+
+func (h *Hub) remove(cl *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[cl]; ok {
+		delete(h.clients, cl)
+		close(cl.done)
+	}
+}
+
+// readPump only exists to process pongs/close frames; clients don't send data
+func (h *Hub) readPump(cl *client) {
+	defer h.remove(cl)
+
+	cl.conn.SetReadDeadline(time.Now().Add(pongWait))
+	cl.conn.SetPongHandler(func(string) error {
+		return cl.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		if _, _, err := cl.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writePump owns all writes to the connection (gorilla allows one writer at a time)
+func (h *Hub) writePump(cl *client) {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		cl.conn.Close()
+	}()
+
+	for {
+		select {
+		case ev := <-cl.send:
+			cl.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := cl.conn.WriteJSON(ev); err != nil {
+				h.remove(cl)
+				return
+			}
+		case <-ticker.C:
+			if err := cl.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				h.remove(cl)
+				return
+			}
+		case <-cl.done:
+			cl.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				time.Now().Add(writeWait))
+			return
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Shutdown priorities: lower runs first
+// Stop taking requests, let in-flight work finish, flush logs, then close the DB
+// Like Spring's SmartLifecycle phases (but ascending instead of descending)
+const (
+	PriorityServer   = 100 // stop accepting requests
+	PriorityWorkers  = 200 // drain in-flight work (background jobs, streams, metrics samplers)
+	PriorityLogs     = 300 // flush buffered logs
+	PriorityDatabase = 400 // close the connection pool
+)
+
+// DefaultGrace is how long an Always component gets when it has no Budget of its own
+const DefaultGrace = 2 * time.Second
+
+// ErrSkipped is reported for components that never ran because the shutdown deadline had passed
+var ErrSkipped = errors.New("skipped: shutdown deadline exceeded")
+
+// Component is anything that needs an orderly stop
+// *http.Server already satisfies it
+type Component interface {
+	Shutdown(ctx context.Context) error
+}
+
+// Func adapts a plain function to Component
+type Func func(ctx context.Context) error
+
+func (f Func) Shutdown(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Option configures one registered component
+type Option func(*entry)
+
+// Budget caps how long the component may take, so one stuck step (e.g. a server
+// waiting on a streaming client) can't use up the whole shutdown deadline
+// Without it the component gets whatever is left of the deadline
+func Budget(d time.Duration) Option {
+	return func(e *entry) {
+		e.budget = d
+	}
+}
+
+// Always runs the component even when the shutdown deadline has already passed,
+// for flush/close steps that must not be lost (logs, the DB pool)
+// It gets its Budget, or DefaultGrace, measured from when it starts
+func Always() Option {
+	return func(e *entry) {
+		e.always = true
+	}
+}
+
+type entry struct {
+	name      string
+	priority  int
+	component Component
+	budget    time.Duration
+	always    bool
+}
+
+// Coordinator shuts registered components down in priority order under one total deadline
+type Coordinator struct {
+	mu      sync.Mutex
+	entries []entry
+}
+
+// New creates an empty Coordinator
+func New() *Coordinator {
+	return &Coordinator{}
+}
+
+// Register adds a component; equal priorities run in registration order
+func (c *Coordinator) Register(name string, priority int, component Component, opts ...Option) {
+	e := entry{name: name, priority: priority, component: component}
+	for _, opt := range opts {
+		opt(&e)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, e)
+}
+
+// RegisterFunc is Register for a plain function
+func (c *Coordinator) RegisterFunc(name string, priority int, fn func(ctx context.Context) error, opts ...Option) {
+	c.Register(name, priority, Func(fn), opts...)
+}
+
+// Shutdown runs every component in order under a total deadline of timeout from now.
+// A component that overruns its budget (or the deadline) is abandoned, its goroutine
+// left to finish on its own, and the next one starts. Once the deadline has passed the
+// remaining components are skipped, except Always ones. Errors are joined, each
+// prefixed with the component name.
+func (c *Coordinator) Shutdown(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return c.ShutdownContext(ctx)
+}
+
+// ShutdownContext is Shutdown with a caller-supplied deadline
+func (c *Coordinator) ShutdownContext(ctx context.Context) error {
+	c.mu.Lock()
+	entries := append([]entry(nil), c.entries...)
+	c.mu.Unlock()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].priority < entries[j].priority
+	})
+
+	var errs []error
+	for _, e := range entries {
+		if ctx.Err() != nil && !e.always {
+			errs = append(errs, fmt.Errorf("%s: %w", e.name, ErrSkipped))
+			continue
+		}
+		stepCtx, cancel := e.context(ctx)
+		if err := run(stepCtx, e.component); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.name, err))
+		}
+		cancel()
+	}
+	return errors.Join(errs...)
+}
+
+// context is the deadline one component runs under
+func (e entry) context(parent context.Context) (context.Context, context.CancelFunc) {
+	if e.always {
+		// Detached from the total deadline, which may already be over
+		budget := e.budget
+		if budget <= 0 {
+			budget = DefaultGrace
+		}
+		return context.WithTimeout(context.WithoutCancel(parent), budget)
+	}
+	if e.budget > 0 {
+		return context.WithTimeout(parent, e.budget)
+	}
+	return context.WithCancel(parent)
+}
+
+// run calls component.Shutdown but stops waiting once ctx is done,
+// so one component that ignores its context can't hold up the rest
+func run(ctx context.Context, component Component) error {
+	done := make(chan error, 1) // buffered: Shutdown may finish after we stop waiting
+	go func() {
+		done <- component.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
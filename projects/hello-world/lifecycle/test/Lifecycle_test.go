@@ -0,0 +1,153 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/lifecycle"
+)
+
+// recorder notes the order components were shut down in
+type recorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *recorder) component(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.order = append(r.order, name)
+		return nil
+	}
+}
+
+func (r *recorder) got() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.order...)
+}
+
+func TestShutdownRunsInPriorityOrder(t *testing.T) {
+	rec := &recorder{}
+	c := lifecycle.New()
+
+	// Registered out of order on purpose
+	c.RegisterFunc("database", lifecycle.PriorityDatabase, rec.component("database"))
+	c.RegisterFunc("logs", lifecycle.PriorityLogs, rec.component("logs"))
+	c.RegisterFunc("http", lifecycle.PriorityServer, rec.component("http"))
+	c.RegisterFunc("sse", lifecycle.PriorityWorkers, rec.component("sse"))
+	c.RegisterFunc("metrics", lifecycle.PriorityWorkers, rec.component("metrics"))
+
+	if err := c.Shutdown(time.Second); err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+
+	want := []string{"http", "sse", "metrics", "logs", "database"}
+	got := rec.got()
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected order %v, got %v", want, got)
+	}
+}
+
+// stuck ignores its context, like an http.Server waiting on a streaming client
+func stuck(ctx context.Context) error {
+	time.Sleep(5 * time.Second)
+	return nil
+}
+
+func TestShutdownCutsOffSlowComponent(t *testing.T) {
+	rec := &recorder{}
+	c := lifecycle.New()
+
+	c.RegisterFunc("http", lifecycle.PriorityServer, rec.component("http"))
+	c.RegisterFunc("stuck", lifecycle.PriorityWorkers, stuck)
+	c.RegisterFunc("metrics", lifecycle.PriorityWorkers, rec.component("metrics"))
+	c.RegisterFunc("logs", lifecycle.PriorityLogs, rec.component("logs"), lifecycle.Always())
+	c.RegisterFunc("database", lifecycle.PriorityDatabase, rec.component("database"), lifecycle.Always())
+
+	start := time.Now()
+	err := c.Shutdown(50 * time.Millisecond)
+	elapsed := time.Since(start)
+
+	if elapsed > time.Second {
+		t.Errorf("Expected shutdown to stop at the 50ms deadline, took %s", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "stuck") {
+		t.Errorf("Expected deadline error naming stuck, got %v", err)
+	}
+	if !errors.Is(err, lifecycle.ErrSkipped) || !strings.Contains(err.Error(), "metrics: skipped") {
+		t.Errorf("Expected metrics reported as skipped, got %v", err)
+	}
+
+	// Flushing logs and closing the DB still happen after the deadline
+	want := []string{"http", "logs", "database"}
+	if got := rec.got(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v to run, got %v", want, got)
+	}
+}
+
+func TestShutdownBudgetLeavesTimeForLaterComponents(t *testing.T) {
+	rec := &recorder{}
+	c := lifecycle.New()
+
+	c.RegisterFunc("http", lifecycle.PriorityServer, stuck, lifecycle.Budget(30*time.Millisecond))
+	c.RegisterFunc("metrics", lifecycle.PriorityWorkers, rec.component("metrics"))
+	c.RegisterFunc("database", lifecycle.PriorityDatabase, rec.component("database"))
+
+	err := c.Shutdown(time.Second)
+
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "http") {
+		t.Errorf("Expected http to overrun its budget, got %v", err)
+	}
+	if errors.Is(err, lifecycle.ErrSkipped) {
+		t.Errorf("Expected nothing skipped, got %v", err)
+	}
+	want := []string{"metrics", "database"}
+	if got := rec.got(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v to run, got %v", want, got)
+	}
+}
+
+func TestAlwaysComponentGetsGraceAfterDeadline(t *testing.T) {
+	c := lifecycle.New()
+	c.RegisterFunc("stuck", lifecycle.PriorityServer, stuck)
+
+	var remaining time.Duration
+	c.RegisterFunc("logs", lifecycle.PriorityLogs, func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		remaining = time.Until(deadline)
+		return ctx.Err()
+	}, lifecycle.Always(), lifecycle.Budget(500*time.Millisecond))
+
+	err := c.Shutdown(20 * time.Millisecond)
+	if strings.Contains(err.Error(), "logs") {
+		t.Errorf("Expected logs to run with a live context, got %v", err)
+	}
+	if remaining < 400*time.Millisecond || remaining > 500*time.Millisecond {
+		t.Errorf("Expected about 500ms for logs, got %s", remaining)
+	}
+}
+
+func TestShutdownJoinsErrors(t *testing.T) {
+	c := lifecycle.New()
+	errFlush := errors.New("flush failed")
+	c.RegisterFunc("logs", lifecycle.PriorityLogs, func(ctx context.Context) error { return errFlush })
+	ran := false
+	c.RegisterFunc("database", lifecycle.PriorityDatabase, func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+
+	err := c.Shutdown(time.Second)
+	if !errors.Is(err, errFlush) || !strings.Contains(err.Error(), "logs: flush failed") {
+		t.Errorf("Expected logs: flush failed, got %v", err)
+	}
+	if !ran {
+		t.Error("Expected database to close after a failed log flush")
+	}
+}
@@ -0,0 +1,172 @@
+package validation
+
+import (
+	"cmp"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// FieldError describes why one field is invalid
+// Like a Bean Validation ConstraintViolation (property path + message)
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Errors is every FieldError found by one Validator
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Rule checks one value and returns a message when it is invalid, "" otherwise
+type Rule func() string
+
+// WithMessage replaces the rule's message, e.g. to add a hint for the user
+func (r Rule) WithMessage(message string) Rule {
+	return func() string {
+		if r() == "" {
+			return ""
+		}
+		return message
+	}
+}
+
+// Validator collects field errors so callers can report all of them at once
+//
+//	err := validation.New().
+//		Check("name", validation.NotEmpty(name)).
+//		Check("age", validation.Range(age, 0, 150)).
+//		Err()
+type Validator struct {
+	errs Errors
+}
+
+// New creates an empty Validator
+func New() *Validator {
+	return &Validator{}
+}
+
+// Check runs rules for field in order and records the first failure
+// Later rules usually assume earlier ones passed (e.g. NotEmpty before Email)
+func (v *Validator) Check(field string, rules ...Rule) *Validator {
+	for _, rule := range rules {
+		if msg := rule(); msg != "" {
+			v.errs = append(v.errs, FieldError{Field: field, Message: msg})
+			break
+		}
+	}
+	return v
+}
+
+// Valid reports whether every check passed
+func (v *Validator) Valid() bool {
+	return len(v.errs) == 0
+}
+
+// Err returns the collected Errors, or nil when valid
+// Returns a plain nil (not a nil Errors) so err != nil works as expected
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return v.errs
+}
+
+// NotEmpty fails for empty or whitespace-only strings
+func NotEmpty(s string) Rule {
+	return func() string {
+		if strings.TrimSpace(s) == "" {
+			return "is required"
+		}
+		return ""
+	}
+}
+
+// MinLength fails for strings shorter than n characters
+func MinLength(s string, n int) Rule {
+	return func() string {
+		if len([]rune(s)) < n {
+			return fmt.Sprintf("must be at least %d characters", n)
+		}
+		return ""
+	}
+}
+
+// Email fails unless s is a bare address like user@example.com
+func Email(s string) Rule {
+	return func() string {
+		addr, err := mail.ParseAddress(s)
+		// ParseAddress also accepts "Name <user@example.com>"; we want just the address
+		if err != nil || addr.Address != s {
+			return "must be a valid email address"
+		}
+		return ""
+	}
+}
+
+// Range fails unless min <= n <= max
+func Range[T cmp.Ordered](n, min, max T) Rule {
+	return func() string {
+		if n < min || n > max {
+			return fmt.Sprintf("must be between %v and %v", min, max)
+		}
+		return ""
+	}
+}
+
+// Min fails when n < min
+func Min[T cmp.Ordered](n, min T) Rule {
+	return func() string {
+		if n < min {
+			return fmt.Sprintf("must be at least %v", min)
+		}
+		return ""
+	}
+}
+
+// Positive fails when n <= 0; works for ints, floats and time.Duration
+func Positive[T cmp.Ordered](n T) Rule {
+	return func() string {
+		var zero T
+		if n <= zero {
+			return "must be positive"
+		}
+		return ""
+	}
+}
+
+// OneOf fails unless v is one of allowed, e.g. a log level or an enum-like setting
+func OneOf[T comparable](v T, allowed ...T) Rule {
+	return func() string {
+		names := make([]string, len(allowed))
+		for i, a := range allowed {
+			if v == a {
+				return ""
+			}
+			names[i] = fmt.Sprint(a)
+		}
+		return "must be one of " + strings.Join(names, ", ")
+	}
+}
+
+// Matches fails when s doesn't match re; message explains the expected format
+func Matches(s string, re *regexp.Regexp, message string) Rule {
+	return func() string {
+		if !re.MatchString(s) {
+			return message
+		}
+		return ""
+	}
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/viraj/go-mono-repo/projects/hello-world/validation"
+)
+
+func TestRules(t *testing.T) {
+	slug := regexp.MustCompile(`^[a-z0-9-]+$`)
+
+	tests := []struct {
+		name    string
+		rule    validation.Rule
+		wantMsg string
+	}{
+		{"not empty ok", validation.NotEmpty("viraj"), ""},
+		{"not empty blank", validation.NotEmpty("   "), "is required"},
+		{"min length ok", validation.MinLength("abc", 3), ""},
+		{"min length counts runes", validation.MinLength("éé", 3), "must be at least 3 characters"},
+		{"email ok", validation.Email("user@example.com"), ""},
+		{"email missing @", validation.Email("user.example.com"), "must be a valid email address"},
+		{"email with display name", validation.Email("User <user@example.com>"), "must be a valid email address"},
+		{"range ok", validation.Range(30, 0, 150), ""},
+		{"range inclusive", validation.Range(150, 0, 150), ""},
+		{"range too high", validation.Range(151, 0, 150), "must be between 0 and 150"},
+		{"min ok", validation.Min(0, 0), ""},
+		{"min too low", validation.Min(-1, 0), "must be at least 0"},
+		{"positive duration", validation.Positive(time.Second), ""},
+		{"zero duration", validation.Positive(time.Duration(0)), "must be positive"},
+		{"one of ok", validation.OneOf("json", "json", "console"), ""},
+		{"one of fails", validation.OneOf("xml", "json", "console"), "must be one of json, console"},
+		{"matches ok", validation.Matches("my-slug", slug, "must be a slug"), ""},
+		{"matches fails", validation.Matches("My Slug", slug, "must be a slug"), "must be a slug"},
+		{"custom message", validation.NotEmpty("").WithMessage("is required (set JWT_SECRET)"), "is required (set JWT_SECRET)"},
+		{"custom message on valid", validation.NotEmpty("x").WithMessage("unused"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule(); got != tt.wantMsg {
+				t.Errorf("Expected %q, got %q", tt.wantMsg, got)
+			}
+		})
+	}
+}
+
+func validateUser(name, email string, age int) error {
+	return validation.New().
+		Check("name", validation.NotEmpty(name), validation.MinLength(name, 2)).
+		Check("email", validation.NotEmpty(email), validation.Email(email)).
+		Check("age", validation.Range(age, 0, 150)).
+		Err()
+}
+
+func TestValidateUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		userName   string
+		email      string
+		age        int
+		wantFields map[string]string
+	}{
+		{"valid user", "Viraj", "viraj@example.com", 30, nil},
+		{"every field invalid", "", "not-an-email", 200, map[string]string{
+			"name":  "is required",
+			"email": "must be a valid email address",
+			"age":   "must be between 0 and 150",
+		}},
+		{"only first failing rule per field", "", "", 30, map[string]string{
+			"name":  "is required",
+			"email": "is required",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUser(tt.userName, tt.email, tt.age)
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var errs validation.Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Expected validation.Errors, got %T: %v", err, err)
+			}
+			if len(errs) != len(tt.wantFields) {
+				t.Fatalf("Expected %d field errors, got %v", len(tt.wantFields), errs)
+			}
+			for _, fe := range errs {
+				if want := tt.wantFields[fe.Field]; fe.Message != want {
+					t.Errorf("Expected %s: %q, got %q", fe.Field, want, fe.Message)
+				}
+			}
+		})
+	}
+}